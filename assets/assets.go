@@ -0,0 +1,158 @@
+// Package assets enumerates the sprite and cry files linked from Pokemon and
+// downloads them into a local content-addressed store.
+//
+//	store, err := assets.NewStore("./assets", sdk)
+//	if err != nil {
+//		panic(err)
+//	}
+//	for result := range store.DownloadAll(ctx, assets.Enumerate(pika), 4) {
+//		if result.Error != nil {
+//			log.Printf("failed to download %s: %v", result.Asset.URL, result.Error)
+//		}
+//	}
+package assets
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/danielgtaylor/pokesdk"
+)
+
+// Kind is the kind of an asset.
+type Kind string
+
+// Asset kinds.
+const (
+	KindSprite Kind = "sprite"
+	KindCry    Kind = "cry"
+)
+
+// Asset is a single sprite or cry file for a Pokemon along with the variant
+// information describing it.
+type Asset struct {
+	Pokemon string `json:"pokemon"`
+	Kind    Kind   `json:"kind"`
+
+	// Style is the artwork style of a sprite, e.g. `official-artwork`, or the
+	// cry variant, e.g. `latest`. Default sprites have the style `default`.
+	Style string `json:"style"`
+
+	// Generation and Game are set for game-specific sprites, e.g.
+	// `generation-v` and `black-white`.
+	Generation string `json:"generation,omitempty"`
+	Game       string `json:"game,omitempty"`
+
+	// Side is `front` or `back` for sprites.
+	Side     string `json:"side,omitempty"`
+	Shiny    bool   `json:"shiny,omitempty"`
+	Female   bool   `json:"female,omitempty"`
+	Animated bool   `json:"animated,omitempty"`
+
+	// Variant holds any extra sprite qualifiers, e.g. `gray`.
+	Variant string `json:"variant,omitempty"`
+
+	// Key is the original key of the asset in the API response.
+	Key string `json:"key"`
+	URL string `json:"url"`
+}
+
+// Name returns a unique, human-readable, slash-separated name for the asset,
+// suitable for use as a relative file path.
+func (a Asset) Name() string {
+	parts := []string{a.Pokemon, string(a.Kind), a.Style}
+	if a.Generation != "" {
+		parts = append(parts, a.Generation, a.Game)
+	}
+	if a.Animated {
+		parts = append(parts, "animated")
+	}
+	return strings.Join(parts, "/") + "/" + a.Key + path.Ext(a.URL)
+}
+
+// newSprite creates a sprite asset by parsing variant information from a key
+// such as `front_shiny_female`.
+func newSprite(pokemon, style, key, url string) Asset {
+	a := Asset{
+		Pokemon: pokemon,
+		Kind:    KindSprite,
+		Style:   style,
+		Key:     key,
+		URL:     url,
+	}
+
+	variants := []string{}
+	for _, part := range strings.Split(key, "_") {
+		switch part {
+		case "front", "back":
+			a.Side = part
+		case "shiny":
+			a.Shiny = true
+		case "female":
+			a.Female = true
+		case "default":
+		default:
+			variants = append(variants, part)
+		}
+	}
+	a.Variant = strings.Join(variants, "_")
+
+	return a
+}
+
+// Enumerate returns every non-empty sprite and cry asset for a Pokemon in a
+// stable order.
+func Enumerate(p *pokesdk.Pokemon) []Asset {
+	found := []Asset{}
+
+	p.Sprites.Each(func(s pokesdk.SpriteURL) bool {
+		parts := strings.Split(s.Path, "/")
+		key := parts[len(parts)-1]
+		style := "default"
+		gen, game := "", ""
+		nested := []string{}
+
+		switch {
+		case parts[0] == "other" && len(parts) > 2:
+			style = parts[1]
+			nested = parts[2 : len(parts)-1]
+		case parts[0] == "versions" && len(parts) > 3:
+			gen, game = parts[1], parts[2]
+			nested = parts[3 : len(parts)-1]
+		}
+
+		a := newSprite(p.Name, style, key, s.URL)
+		a.Generation, a.Game = gen, game
+		for _, n := range nested {
+			// Nested sets such as `animated` hold more sprites.
+			if n == "animated" {
+				a.Animated = true
+			} else {
+				a.Style = n
+			}
+		}
+		found = append(found, a)
+		return true
+	})
+
+	keys := make([]string, 0, len(p.Cries))
+	for k := range p.Cries {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if url := p.Cries[key]; url != "" {
+			found = append(found, Asset{
+				Pokemon: p.Name,
+				Kind:    KindCry,
+				Style:   key,
+				Key:     key,
+				URL:     url,
+			})
+		}
+	}
+
+	return found
+}
@@ -0,0 +1,80 @@
+package assets_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/danielgtaylor/pokesdk"
+	"github.com/danielgtaylor/pokesdk/assets"
+)
+
+const pikachuSprites = `{
+	"name": "pikachu",
+	"sprites": {
+		"front_default": "https://example.com/25.png",
+		"front_female": "https://example.com/female/25.png",
+		"front_shiny": null,
+		"back_default": "https://example.com/back/25.png",
+		"other": {
+			"official-artwork": {"front_default": "https://example.com/artwork/25.png", "front_shiny": null}
+		},
+		"versions": {
+			"generation-i": {
+				"red-blue": {"front_default": "https://example.com/i/25.png", "front_gray": "https://example.com/i/gray/25.png"}
+			},
+			"generation-v": {
+				"black-white": {
+					"animated": {"front_shiny": "https://example.com/v/anim/shiny/25.gif", "back_default": null},
+					"front_default": "https://example.com/v/25.png"
+				}
+			}
+		}
+	},
+	"cries": {"latest": "https://example.com/cries/25.ogg", "legacy": null}
+}`
+
+func loadPikachu(t *testing.T) *pokesdk.Pokemon {
+	var pika pokesdk.Pokemon
+	if err := json.Unmarshal([]byte(pikachuSprites), &pika); err != nil {
+		t.Fatal(err)
+	}
+	return &pika
+}
+
+func TestEnumerate(t *testing.T) {
+	found := assets.Enumerate(loadPikachu(t))
+
+	names := map[string]assets.Asset{}
+	for _, a := range found {
+		names[a.Name()] = a
+	}
+
+	if len(found) != 9 || len(names) != 9 {
+		t.Fatalf("expected 9 unique assets, got %d: %v", len(found), names)
+	}
+
+	female := names["pikachu/sprite/default/front_female.png"]
+	if !female.Female || female.Side != "front" || female.Shiny {
+		t.Errorf("unexpected female sprite: %+v", female)
+	}
+
+	artwork := names["pikachu/sprite/official-artwork/front_default.png"]
+	if artwork.URL != "https://example.com/artwork/25.png" {
+		t.Errorf("unexpected artwork: %+v", artwork)
+	}
+
+	gray := names["pikachu/sprite/default/generation-i/red-blue/front_gray.png"]
+	if gray.Variant != "gray" || gray.Generation != "generation-i" || gray.Game != "red-blue" {
+		t.Errorf("unexpected gray sprite: %+v", gray)
+	}
+
+	animated := names["pikachu/sprite/default/generation-v/black-white/animated/front_shiny.gif"]
+	if !animated.Animated || !animated.Shiny {
+		t.Errorf("unexpected animated sprite: %+v", animated)
+	}
+
+	cry := names["pikachu/cry/latest/latest.ogg"]
+	if cry.Kind != assets.KindCry {
+		t.Errorf("unexpected cry: %+v", cry)
+	}
+}
@@ -0,0 +1,234 @@
+package assets
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/danielgtaylor/pokesdk"
+)
+
+// DefaultHosts are the hosts which serve the public API's sprites and cries.
+var DefaultHosts = []string{"raw.githubusercontent.com"}
+
+// Store is a content-addressed local asset store. Files are saved once per
+// unique content under `objects/`, and each downloaded URL is recorded under
+// `refs/` so that downloads can be skipped or resumed later.
+type Store struct {
+	// AllowedHosts lists hosts which assets may be downloaded from besides
+	// those the SDK allows, and defaults to `DefaultHosts`. Downloads from
+	// other hosts fail with `pokesdk.DisallowedURLError`, which protects
+	// against poisoned links from a mirror or cache.
+	AllowedHosts []string
+
+	dir string
+	sdk *pokesdk.SDK
+}
+
+// NewStore creates a new asset store in the given directory, downloading
+// assets through the given SDK's client.
+func NewStore(dir string, sdk *pokesdk.SDK) (*Store, error) {
+	for _, sub := range []string{"objects", "refs", "tmp"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create store: %w", err)
+		}
+	}
+	return &Store{AllowedHosts: append([]string{}, DefaultHosts...), dir: dir, sdk: sdk}, nil
+}
+
+// resolve returns the URL to download an asset from, if it is allowed by
+// either the SDK or `AllowedHosts`.
+func (s *Store) resolve(raw string) (string, error) {
+	resolved, err := s.sdk.ResolveURL(raw)
+	if err == nil {
+		return resolved, nil
+	}
+
+	u, perr := url.Parse(s.sdk.RewriteURL(raw))
+	if perr != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return "", err
+	}
+	for _, host := range s.AllowedHosts {
+		if strings.EqualFold(host, u.Host) {
+			return u.String(), nil
+		}
+	}
+	return "", err
+}
+
+func hash(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+func (s *Store) refPath(url string) string {
+	return filepath.Join(s.dir, "refs", hash([]byte(url)))
+}
+
+func (s *Store) objectPath(digest string) string {
+	return filepath.Join(s.dir, "objects", digest[:2], digest)
+}
+
+// Lookup returns the local path of a previously downloaded URL, if any.
+func (s *Store) Lookup(url string) (string, bool) {
+	digest, err := os.ReadFile(s.refPath(url))
+	if err != nil {
+		return "", false
+	}
+
+	p := s.objectPath(strings.TrimSpace(string(digest)))
+	if _, err := os.Stat(p); err != nil {
+		return "", false
+	}
+	return p, true
+}
+
+// Open opens a previously downloaded URL for reading.
+func (s *Store) Open(url string) (io.ReadCloser, error) {
+	p, ok := s.Lookup(url)
+	if !ok {
+		return nil, fmt.Errorf("asset %s: %w", url, os.ErrNotExist)
+	}
+	return os.Open(p)
+}
+
+// Download fetches the asset unless it is already in the store and returns
+// its local path. Identical content from different URLs is stored once.
+func (s *Store) Download(ctx context.Context, asset Asset) (string, error) {
+	if p, ok := s.Lookup(asset.URL); ok {
+		return p, nil
+	}
+
+	u, err := s.resolve(asset.URL)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := s.sdk.Request(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return "", fmt.Errorf("status %d response: %w", resp.StatusCode, pokesdk.APIError)
+	}
+
+	// Write to a temporary file first so that interrupted downloads never
+	// leave partial objects behind.
+	tmp, err := os.CreateTemp(filepath.Join(s.dir, "tmp"), "download-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	h := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(tmp, h), resp.Body); err != nil {
+		tmp.Close()
+		return "", fmt.Errorf("failed to download %s: %w", asset.URL, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", asset.URL, err)
+	}
+
+	digest := hex.EncodeToString(h.Sum(nil))
+	p := s.objectPath(digest)
+	if _, err := os.Stat(p); errors.Is(err, os.ErrNotExist) {
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			return "", fmt.Errorf("failed to create object dir: %w", err)
+		}
+		if err := os.Rename(tmp.Name(), p); err != nil {
+			return "", fmt.Errorf("failed to store %s: %w", asset.URL, err)
+		}
+	}
+
+	if err := os.WriteFile(s.refPath(asset.URL), []byte(digest), 0o644); err != nil {
+		return "", fmt.Errorf("failed to record %s: %w", asset.URL, err)
+	}
+
+	return p, nil
+}
+
+// DownloadResult is the result of downloading a single asset in bulk.
+type DownloadResult struct {
+	Asset Asset
+
+	// Path is the local path of the downloaded asset.
+	Path string
+
+	// Cached is true if the asset was already in the store.
+	Cached bool
+
+	Error error
+}
+
+// DownloadAll downloads the given assets using up to `workers` concurrent
+// requests and sends a result for each on the returned channel, which is
+// closed when all downloads are complete. Assets already in the store are
+// skipped, so an interrupted bulk download can be resumed by calling this
+// again. Duplicate URLs are only downloaded once. Callers that stop reading
+// before the channel is closed must cancel `ctx` to release the workers.
+func (s *Store) DownloadAll(ctx context.Context, assets []Asset, workers int) chan DownloadResult {
+	if workers < 1 {
+		workers = 1
+	}
+
+	ch := make(chan DownloadResult, workers)
+	queue := make(chan Asset)
+
+	// Downloads of the same URL are serialized so that duplicates become
+	// cache hits rather than redundant requests.
+	locks := map[string]*sync.Mutex{}
+	for _, a := range assets {
+		if locks[a.URL] == nil {
+			locks[a.URL] = &sync.Mutex{}
+		}
+	}
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for a := range queue {
+				lock := locks[a.URL]
+				lock.Lock()
+				p, cached := s.Lookup(a.URL)
+				var err error
+				if !cached {
+					p, err = s.Download(ctx, a)
+				}
+				lock.Unlock()
+				select {
+				case <-ctx.Done():
+					return
+				case ch <- DownloadResult{Asset: a, Path: p, Cached: cached, Error: err}:
+				}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(ch)
+		defer wg.Wait()
+		defer close(queue)
+		for _, a := range assets {
+			select {
+			case <-ctx.Done():
+				return
+			case queue <- a:
+			}
+		}
+	}()
+
+	return ch
+}
@@ -0,0 +1,188 @@
+package assets_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/pokesdk"
+	"github.com/danielgtaylor/pokesdk/assets"
+)
+
+func fakeServer(t *testing.T, hits *int64) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(hits, 1)
+		switch r.URL.Path {
+		case "/a.png", "/copy-of-a.png":
+			w.Write([]byte("sprite-a"))
+		case "/b.png":
+			w.Write([]byte("sprite-b"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// newStore creates a store which may download from the fake server.
+func newStore(t *testing.T, dir string, server *httptest.Server) *assets.Store {
+	store, err := assets.NewStore(dir, pokesdk.New(pokesdk.Config{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.AllowedHosts = append(store.AllowedHosts, server.Listener.Addr().String())
+	return store
+}
+
+func TestDownload(t *testing.T) {
+	ctx := context.Background()
+
+	var hits int64
+	server := fakeServer(t, &hits)
+	store := newStore(t, t.TempDir(), server)
+
+	p, err := store.Download(ctx, assets.Asset{URL: server.URL + "/a.png"})
+	if err != nil {
+		t.Fatalf("failed to download: %v", err)
+	}
+
+	b, err := os.ReadFile(p)
+	if err != nil || string(b) != "sprite-a" {
+		t.Errorf("unexpected content %q: %v", b, err)
+	}
+
+	// Same content at a different URL must be stored once.
+	p2, err := store.Download(ctx, assets.Asset{URL: server.URL + "/copy-of-a.png"})
+	if err != nil {
+		t.Fatalf("failed to download: %v", err)
+	}
+	if p != p2 {
+		t.Errorf("expected deduplicated path, got %s and %s", p, p2)
+	}
+
+	// Already downloaded URLs do not make new requests.
+	if _, err := store.Download(ctx, assets.Asset{URL: server.URL + "/a.png"}); err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadInt64(&hits); n != 2 {
+		t.Errorf("expected 2 requests, got %d", n)
+	}
+
+	r, err := store.Open(server.URL + "/copy-of-a.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	if b, _ := io.ReadAll(r); string(b) != "sprite-a" {
+		t.Errorf("unexpected content %q", b)
+	}
+
+	if _, err := store.Download(ctx, assets.Asset{URL: server.URL + "/missing.png"}); err == nil {
+		t.Errorf("expected error for missing asset")
+	}
+}
+
+func TestDownloadAll(t *testing.T) {
+	ctx := context.Background()
+
+	var hits int64
+	server := fakeServer(t, &hits)
+	dir := t.TempDir()
+	store := newStore(t, dir, server)
+
+	// Simulate a previous, interrupted run which only fetched one asset.
+	if _, err := store.Download(ctx, assets.Asset{URL: server.URL + "/a.png"}); err != nil {
+		t.Fatal(err)
+	}
+
+	list := []assets.Asset{
+		{URL: server.URL + "/a.png"},
+		{URL: server.URL + "/b.png"},
+		{URL: server.URL + "/b.png"},
+		{URL: server.URL + "/missing.png"},
+	}
+
+	cached, failed, downloaded := 0, 0, 0
+	for result := range store.DownloadAll(ctx, list, 3) {
+		switch {
+		case result.Error != nil:
+			failed++
+		case result.Cached:
+			cached++
+		default:
+			downloaded++
+		}
+	}
+
+	if cached != 2 || downloaded != 1 || failed != 1 {
+		t.Errorf("unexpected results: cached=%d downloaded=%d failed=%d", cached, downloaded, failed)
+	}
+
+	if n := atomic.LoadInt64(&hits); n != 3 {
+		t.Errorf("expected 3 requests, got %d", n)
+	}
+
+	objects, _ := filepath.Glob(filepath.Join(dir, "objects", "*", "*"))
+	if len(objects) != 2 {
+		t.Errorf("expected 2 stored objects, got %d", len(objects))
+	}
+}
+
+func TestDownloadAllCancel(t *testing.T) {
+	var hits int64
+	server := fakeServer(t, &hits)
+	store := newStore(t, t.TempDir(), server)
+
+	list := []assets.Asset{}
+	for i := 0; i < 20; i++ {
+		list = append(list, assets.Asset{URL: fmt.Sprintf("%s/missing-%d.png", server.URL, i)})
+	}
+
+	// Stop reading after the first result, then cancel. The channel must
+	// still be closed once the workers notice.
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := store.DownloadAll(ctx, list, 2)
+	<-ch
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		for range ch {
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("workers did not exit after cancellation")
+	}
+}
+
+func TestDownloadDisallowed(t *testing.T) {
+	var hits int64
+	server := fakeServer(t, &hits)
+	store, err := assets.NewStore(t.TempDir(), pokesdk.New(pokesdk.Config{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, url := range []string{server.URL + "/a.png", "file:///etc/passwd"} {
+		_, err := store.Download(context.Background(), assets.Asset{URL: url})
+		if !errors.Is(err, pokesdk.DisallowedURLError) {
+			t.Errorf("expected %s to be disallowed, got %v", url, err)
+		}
+	}
+	if n := atomic.LoadInt64(&hits); n != 0 {
+		t.Errorf("expected no requests, got %d", n)
+	}
+}
@@ -0,0 +1,161 @@
+package pokesdk
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// DefaultBatchConcurrency is the default number of concurrent requests made by
+// batch methods like `FollowBatch`.
+var DefaultBatchConcurrency = 8
+
+type batchConfig struct {
+	concurrency int
+	failFast    bool
+	callOptions []CallOption
+}
+
+// BatchOption configures a batch call.
+type BatchOption func(*batchConfig)
+
+// BatchConcurrency sets the maximum number of concurrent requests.
+func BatchConcurrency(n int) BatchOption {
+	return func(c *batchConfig) {
+		c.concurrency = n
+	}
+}
+
+// BatchFailFast stops the batch at the first failure instead of collecting
+// all failures. Items which were not fetched are left nil.
+func BatchFailFast() BatchOption {
+	return func(c *batchConfig) {
+		c.failFast = true
+	}
+}
+
+// BatchCallOptions applies call options, such as `WithTimeout`, to each
+// item's request.
+func BatchCallOptions(opts ...CallOption) BatchOption {
+	return func(c *batchConfig) {
+		c.callOptions = append(c.callOptions, opts...)
+	}
+}
+
+// BatchItemError is the failure of a single item in a batch.
+type BatchItemError struct {
+	Index int
+	URL   string
+	Err   error
+}
+
+func (e *BatchItemError) Error() string {
+	return fmt.Sprintf("item %d (%s): %v", e.Index, e.URL, e.Err)
+}
+
+func (e *BatchItemError) Unwrap() error {
+	return e.Err
+}
+
+// BatchError aggregates the failures of a batch call, ordered by index. Use
+// `errors.As` to inspect it, or `errors.Is` to check for a specific failure
+// in any of the items.
+//
+//	var batchErr *pokesdk.BatchError
+//	if errors.As(err, &batchErr) {
+//		for _, e := range batchErr.Errors {
+//			fmt.Println(names[e.Index], e.Err)
+//		}
+//	}
+type BatchError struct {
+	Errors []*BatchItemError
+}
+
+func (e *BatchError) Error() string {
+	msgs := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		msgs = append(msgs, err.Error())
+	}
+	return fmt.Sprintf("%d batch items failed: %s", len(e.Errors), strings.Join(msgs, "; "))
+}
+
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// FollowBatch follows many URLs concurrently and returns the decoded values in
+// the same order as the URLs. Values which failed are nil and the failures
+// are returned as a `*BatchError`.
+//
+//	things, err := FollowBatch[Thing](ctx, sdk, urls, BatchConcurrency(4))
+func FollowBatch[T any](ctx context.Context, sdk *SDK, urls []string, opts ...BatchOption) ([]*T, error) {
+	config := batchConfig{concurrency: DefaultBatchConcurrency}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	if config.concurrency < 1 {
+		config.concurrency = 1
+	}
+
+	parent := ctx
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([]*T, len(urls))
+	errs := make([]*BatchItemError, len(urls))
+	sem := make(chan struct{}, config.concurrency)
+	wg := sync.WaitGroup{}
+
+	for i, url := range urls {
+		select {
+		case <-ctx.Done():
+		case sem <- struct{}{}:
+		}
+		if ctx.Err() != nil {
+			if parent.Err() != nil {
+				// The caller's context was canceled, so record the remaining
+				// items as failed.
+				errs[i] = &BatchItemError{Index: i, URL: url, Err: parent.Err()}
+			}
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, url string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			value, err := Follow[T](ctx, sdk, url, config.callOptions...)
+			if err != nil {
+				if config.failFast {
+					if ctx.Err() != nil && parent.Err() == nil {
+						// Canceled because another item failed first.
+						return
+					}
+					cancel()
+				}
+				errs[i] = &BatchItemError{Index: i, URL: url, Err: err}
+				return
+			}
+			results[i] = value
+		}(i, url)
+	}
+	wg.Wait()
+
+	batchErr := &BatchError{}
+	for _, err := range errs {
+		if err != nil {
+			batchErr.Errors = append(batchErr.Errors, err)
+		}
+	}
+	if len(batchErr.Errors) > 0 {
+		return results, batchErr
+	}
+
+	return results, nil
+}
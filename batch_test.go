@@ -0,0 +1,129 @@
+package pokesdk_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/danielgtaylor/pokesdk"
+)
+
+func TestGetPokemonBatch(t *testing.T) {
+	ctx := context.Background()
+
+	names := []string{"bulbasaur", "charmander", "squirtle", "pikachu", "eevee"}
+
+	transport := &mockTransport{}
+	for _, name := range names {
+		transport.Expect(
+			"https://pokeapi.co/api/v2/pokemon/"+name,
+			http.StatusOK,
+			fmt.Sprintf(`{"name":%q}`, name),
+		)
+	}
+
+	sdk := pokesdk.New(pokesdk.Config{
+		Client: &http.Client{Transport: transport},
+	})
+
+	pokemon, err := sdk.GetPokemonBatch(ctx, names, pokesdk.BatchConcurrency(2))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(pokemon) != len(names) {
+		t.Fatalf("expected %d results, got %d", len(names), len(pokemon))
+	}
+
+	for i, name := range names {
+		if pokemon[i].Name != name {
+			t.Errorf("expected %s at %d, got %s", name, i, pokemon[i].Name)
+		}
+	}
+}
+
+func TestGetPokemonBatchPartialFailure(t *testing.T) {
+	ctx := context.Background()
+
+	transport := &mockTransport{}
+	transport.Expect("https://pokeapi.co/api/v2/pokemon/pikachu", http.StatusOK, `{"name":"pikachu"}`)
+	transport.Expect("https://pokeapi.co/api/v2/pokemon/missingno", http.StatusNotFound, `Not Found`)
+	transport.Expect("https://pokeapi.co/api/v2/pokemon/eevee", http.StatusOK, `{"name":"eevee"}`)
+
+	sdk := pokesdk.New(pokesdk.Config{
+		Client: &http.Client{Transport: transport},
+	})
+
+	pokemon, err := sdk.GetPokemonBatch(ctx, []string{"pikachu", "missingno", "eevee"})
+
+	var batchErr *pokesdk.BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("expected batch error, got %v", err)
+	}
+
+	if !errors.Is(err, pokesdk.APIError) {
+		t.Errorf("expected API error in batch, got %v", err)
+	}
+
+	if len(batchErr.Errors) != 1 || batchErr.Errors[0].Index != 1 {
+		t.Fatalf("expected a single failure at index 1, got %v", batchErr.Errors)
+	}
+
+	if pokemon[0].Name != "pikachu" || pokemon[1] != nil || pokemon[2].Name != "eevee" {
+		t.Errorf("unexpected results: %v", pokemon)
+	}
+}
+
+func TestFollowBatchFailFast(t *testing.T) {
+	ctx := context.Background()
+
+	transport := &mockTransport{}
+	transport.Expect("https://pokeapi.co/a", http.StatusOK, `{"name":"a"}`)
+	transport.Expect("https://pokeapi.co/b", http.StatusInternalServerError, `Oops`)
+	transport.Expect("https://pokeapi.co/c", http.StatusOK, `{"name":"c"}`)
+
+	sdk := pokesdk.New(pokesdk.Config{
+		Client: &http.Client{Transport: transport},
+	})
+
+	urls := []string{"https://pokeapi.co/a", "https://pokeapi.co/b", "https://pokeapi.co/c"}
+	results, err := pokesdk.FollowBatch[pokesdk.NamedLink](ctx, sdk, urls,
+		pokesdk.BatchConcurrency(1),
+		pokesdk.BatchFailFast(),
+	)
+
+	var batchErr *pokesdk.BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("expected batch error, got %v", err)
+	}
+
+	if len(batchErr.Errors) != 1 || batchErr.Errors[0].URL != "https://pokeapi.co/b" {
+		t.Fatalf("expected a single failure for b, got %v", batchErr.Errors)
+	}
+
+	if results[0] == nil || results[2] != nil {
+		t.Errorf("expected only a to be fetched, got %v", results)
+	}
+
+	if len(transport.requests) != 2 {
+		t.Errorf("expected 2 requests, got %d", len(transport.requests))
+	}
+}
+
+func TestFollowBatchCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	sdk := pokesdk.New(pokesdk.Config{
+		Client: &http.Client{Transport: &mockTransport{}},
+	})
+
+	for _, opts := range [][]pokesdk.BatchOption{nil, {pokesdk.BatchFailFast()}} {
+		_, err := pokesdk.FollowBatch[pokesdk.NamedLink](ctx, sdk, []string{"https://pokeapi.co/a"}, opts...)
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context canceled, got %v", err)
+		}
+	}
+}
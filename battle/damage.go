@@ -0,0 +1,157 @@
+package battle
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/danielgtaylor/pokesdk"
+)
+
+// Category is the damage category of a move.
+type Category string
+
+// Move damage categories.
+const (
+	Physical Category = "physical"
+	Special  Category = "special"
+	Status   Category = "status"
+)
+
+// CriticalChance is the default chance of landing a critical hit.
+var CriticalChance = 1.0 / 24
+
+// CriticalMultiplier is the damage multiplier for critical hits.
+var CriticalMultiplier = 1.5
+
+// Move is the subset of a move's data needed to calculate damage.
+type Move struct {
+	Name     string
+	Type     pokesdk.TypeName
+	Power    int
+	Category Category
+}
+
+// Battler is a Pokemon in battle, with its computed stats at a given level.
+type Battler struct {
+	Name  string
+	Level int
+	Types []pokesdk.TypeName
+	Stats map[pokesdk.StatName]int
+}
+
+// NewBattler creates a battler from a Pokemon, using its types as they were in
+// the given generation (or the current types if empty) and computing its
+// stats from the given level, IVs, EVs and nature.
+func NewBattler(p *pokesdk.Pokemon, level int, generation string, ivs, evs Spread, nature Nature) *Battler {
+	return &Battler{
+		Name:  p.Name,
+		Level: level,
+		Types: typeNames(p.TypesInGeneration(generation)),
+		Stats: ComputeStats(p, level, ivs, evs, nature),
+	}
+}
+
+// HasType returns whether the battler has the given type.
+func (b *Battler) HasType(t pokesdk.TypeName) bool {
+	for _, bt := range b.Types {
+		if bt == t {
+			return true
+		}
+	}
+	return false
+}
+
+// DamageOptions control a single damage calculation.
+type DamageOptions struct {
+	// Critical applies the critical hit multiplier.
+	Critical bool
+
+	// Roll is the random damage roll as a percentage from 85 to 100. Zero is
+	// treated as 100, i.e. maximum damage.
+	Roll int
+}
+
+// Damage is the result of a damage calculation.
+type Damage struct {
+	Amount        int
+	Effectiveness float64
+	STAB          bool
+	Critical      bool
+	Roll          int
+}
+
+// pokeRound rounds to the nearest integer, rounding halves down like the
+// games do.
+func pokeRound(v float64) int {
+	return int(math.Ceil(v - 0.5))
+}
+
+// Damage calculates the damage a move would deal using the standard formula
+// from generation V onward.
+func (c TypeChart) Damage(attacker, defender *Battler, move Move, opts DamageOptions) Damage {
+	roll := opts.Roll
+	if roll == 0 {
+		roll = 100
+	}
+
+	result := Damage{
+		Effectiveness: c.Effectiveness(move.Type, defender.Types...),
+		STAB:          attacker.HasType(move.Type),
+		Critical:      opts.Critical,
+		Roll:          roll,
+	}
+
+	if move.Category == Status || move.Power == 0 {
+		return result
+	}
+
+	attack, defense := pokesdk.StatAttack, pokesdk.StatDefense
+	if move.Category == Special {
+		attack, defense = pokesdk.StatSpecialAttack, pokesdk.StatSpecialDefense
+	}
+
+	a, d := attacker.Stats[attack], defender.Stats[defense]
+	if d == 0 {
+		d = 1
+	}
+
+	damage := (2*attacker.Level/5+2)*move.Power*a/d/50 + 2
+
+	if opts.Critical {
+		damage = pokeRound(float64(damage) * CriticalMultiplier)
+	}
+
+	damage = damage * roll / 100
+
+	if result.STAB {
+		damage = pokeRound(float64(damage) * 1.5)
+	}
+
+	damage = int(float64(damage) * result.Effectiveness)
+
+	if damage < 1 && result.Effectiveness > 0 {
+		damage = 1
+	}
+
+	result.Amount = damage
+	return result
+}
+
+// DamageRange returns the minimum and maximum non-critical damage of a move.
+func (c TypeChart) DamageRange(attacker, defender *Battler, move Move) (int, int) {
+	low := c.Damage(attacker, defender, move, DamageOptions{Roll: 85})
+	high := c.Damage(attacker, defender, move, DamageOptions{Roll: 100})
+	return low.Amount, high.Amount
+}
+
+// RollDamage calculates damage with a random roll and a chance of a critical
+// hit, using the given source of randomness.
+//
+//	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+//	dmg := chart.RollDamage(r, attacker, defender, move)
+func (c TypeChart) RollDamage(r *rand.Rand, attacker, defender *Battler, move Move) Damage {
+	return c.Damage(attacker, defender, move, DamageOptions{
+		Critical: r.Float64() < CriticalChance,
+		Roll:     85 + r.Intn(16),
+	})
+}
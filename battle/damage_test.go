@@ -0,0 +1,87 @@
+package battle_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/danielgtaylor/pokesdk"
+	"github.com/danielgtaylor/pokesdk/battle"
+)
+
+var thunderbolt = battle.Move{
+	Name:     "thunderbolt",
+	Type:     pokesdk.TypeElectric,
+	Power:    90,
+	Category: battle.Special,
+}
+
+func TestDamage(t *testing.T) {
+	chart := battle.NewTypeChart(testTypes, "")
+
+	attacker := &battle.Battler{
+		Level: 50,
+		Types: []pokesdk.TypeName{pokesdk.TypeElectric},
+		Stats: map[pokesdk.StatName]int{pokesdk.StatSpecialAttack: 120},
+	}
+	defender := &battle.Battler{
+		Level: 50,
+		Types: []pokesdk.TypeName{pokesdk.TypeWater},
+		Stats: map[pokesdk.StatName]int{pokesdk.StatSpecialDefense: 100},
+	}
+
+	low, high := chart.DamageRange(attacker, defender, thunderbolt)
+	if low != 122 || high != 146 {
+		t.Errorf("expected 122-146 damage, got %d-%d", low, high)
+	}
+
+	crit := chart.Damage(attacker, defender, thunderbolt, battle.DamageOptions{Critical: true})
+	if crit.Amount != 218 || !crit.STAB || crit.Effectiveness != 2 {
+		t.Errorf("unexpected critical damage: %+v", crit)
+	}
+
+	defender.Types = []pokesdk.TypeName{pokesdk.TypeGround}
+	if d := chart.Damage(attacker, defender, thunderbolt, battle.DamageOptions{}); d.Amount != 0 {
+		t.Errorf("expected no damage against ground, got %d", d.Amount)
+	}
+
+	status := battle.Move{Type: pokesdk.TypeElectric, Category: battle.Status}
+	if d := chart.Damage(attacker, defender, status, battle.DamageOptions{}); d.Amount != 0 {
+		t.Errorf("expected no damage from status move, got %d", d.Amount)
+	}
+}
+
+func TestRollDamage(t *testing.T) {
+	chart := battle.NewTypeChart(testTypes, "")
+
+	pikachu := &pokesdk.Pokemon{
+		Name:  "pikachu",
+		Types: []pokesdk.Types{{Slot: 1, Type: pokesdk.TypeLink{Name: pokesdk.TypeElectric}}},
+		Stats: stats(map[pokesdk.StatName]int{pokesdk.StatHP: 35, pokesdk.StatSpecialAttack: 50}),
+	}
+	gyarados := &pokesdk.Pokemon{
+		Name: "gyarados",
+		Types: []pokesdk.Types{
+			{Slot: 1, Type: pokesdk.TypeLink{Name: pokesdk.TypeWater}},
+			{Slot: 2, Type: pokesdk.TypeLink{Name: pokesdk.TypeFlying}},
+		},
+		Stats: stats(map[pokesdk.StatName]int{pokesdk.StatHP: 95, pokesdk.StatSpecialDefense: 100}),
+	}
+
+	attacker := battle.NewBattler(pikachu, 50, "", battle.MaxIVs, nil, battle.Natures["modest"])
+	defender := battle.NewBattler(gyarados, 50, "", battle.MaxIVs, nil, battle.Natures["hardy"])
+
+	low, high := chart.DamageRange(attacker, defender, thunderbolt)
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		d := chart.RollDamage(r, attacker, defender, thunderbolt)
+		if d.Effectiveness != 4 {
+			t.Fatalf("expected 4x effectiveness, got %v", d.Effectiveness)
+		}
+		if d.Roll < 85 || d.Roll > 100 {
+			t.Fatalf("unexpected roll %d", d.Roll)
+		}
+		if !d.Critical && (d.Amount < low || d.Amount > high) {
+			t.Fatalf("damage %d out of range %d-%d", d.Amount, low, high)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package battle
+
+import (
+	"math"
+
+	"github.com/danielgtaylor/pokesdk"
+)
+
+// Spread holds a value per stat, such as individual values (IVs) or effort
+// values (EVs). Missing stats are treated as zero.
+type Spread map[pokesdk.StatName]int
+
+// MaxIVs is a spread of perfect individual values.
+var MaxIVs = Spread{
+	pokesdk.StatHP:             31,
+	pokesdk.StatAttack:         31,
+	pokesdk.StatDefense:        31,
+	pokesdk.StatSpecialAttack:  31,
+	pokesdk.StatSpecialDefense: 31,
+	pokesdk.StatSpeed:          31,
+}
+
+// Nature raises one stat by 10% and lowers another by 10%. Neutral natures
+// raise and lower the same stat, which cancels out.
+type Nature struct {
+	Name      string
+	Increased pokesdk.StatName
+	Decreased pokesdk.StatName
+}
+
+// Modifier returns the nature's multiplier for the given stat.
+func (n Nature) Modifier(stat pokesdk.StatName) float64 {
+	switch {
+	case n.Increased == n.Decreased:
+		return 1
+	case stat == n.Increased:
+		return 1.1
+	case stat == n.Decreased:
+		return 0.9
+	}
+	return 1
+}
+
+// Natures lists all natures by name.
+var Natures = map[string]Nature{}
+
+func init() {
+	stats := []pokesdk.StatName{
+		pokesdk.StatAttack,
+		pokesdk.StatDefense,
+		pokesdk.StatSpeed,
+		pokesdk.StatSpecialAttack,
+		pokesdk.StatSpecialDefense,
+	}
+
+	// Natures are laid out as a grid of increased (rows) and decreased
+	// (columns) stats, in the same order the games use.
+	names := [][]string{
+		{"hardy", "lonely", "brave", "adamant", "naughty"},
+		{"bold", "docile", "relaxed", "impish", "lax"},
+		{"timid", "hasty", "serious", "jolly", "naive"},
+		{"modest", "mild", "quiet", "bashful", "rash"},
+		{"calm", "gentle", "sassy", "careful", "quirky"},
+	}
+	for i, row := range names {
+		for j, name := range row {
+			Natures[name] = Nature{Name: name, Increased: stats[i], Decreased: stats[j]}
+		}
+	}
+}
+
+// ComputeStats calculates a Pokemon's actual stats at a given level using the
+// formula from generation III onward.
+//
+//	stats := battle.ComputeStats(pika, 50, battle.MaxIVs, battle.Spread{pokesdk.StatSpeed: 252}, battle.Natures["timid"])
+func ComputeStats(p *pokesdk.Pokemon, level int, ivs, evs Spread, nature Nature) map[pokesdk.StatName]int {
+	stats := make(map[pokesdk.StatName]int, len(p.Stats))
+	for _, s := range p.Stats {
+		name := s.Stat.Name
+		base := (2*s.BaseStat + ivs[name] + evs[name]/4) * level / 100
+
+		if name == pokesdk.StatHP {
+			if s.BaseStat == 1 {
+				// Shedinja always has exactly one hit point.
+				stats[name] = 1
+				continue
+			}
+			stats[name] = base + level + 10
+			continue
+		}
+
+		// Use integer percentages to avoid floating point rounding errors.
+		percent := int(math.Round(nature.Modifier(name) * 100))
+		stats[name] = (base + 5) * percent / 100
+	}
+	return stats
+}
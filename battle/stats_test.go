@@ -0,0 +1,92 @@
+package battle_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/danielgtaylor/pokesdk"
+	"github.com/danielgtaylor/pokesdk/battle"
+)
+
+func stats(values map[pokesdk.StatName]int) []pokesdk.Stats {
+	s := []pokesdk.Stats{}
+	for _, name := range pokesdk.StatNames {
+		if v, ok := values[name]; ok {
+			s = append(s, pokesdk.Stats{BaseStat: v, Stat: pokesdk.StatLink{Name: name}})
+		}
+	}
+	return s
+}
+
+func TestComputeStats(t *testing.T) {
+	// Example from Bulbapedia's stat article.
+	garchomp := &pokesdk.Pokemon{
+		Name: "garchomp",
+		Stats: stats(map[pokesdk.StatName]int{
+			pokesdk.StatHP:             108,
+			pokesdk.StatAttack:         130,
+			pokesdk.StatDefense:        95,
+			pokesdk.StatSpecialAttack:  80,
+			pokesdk.StatSpecialDefense: 85,
+			pokesdk.StatSpeed:          102,
+		}),
+	}
+
+	ivs := battle.Spread{
+		pokesdk.StatHP:             24,
+		pokesdk.StatAttack:         12,
+		pokesdk.StatDefense:        30,
+		pokesdk.StatSpecialAttack:  16,
+		pokesdk.StatSpecialDefense: 23,
+		pokesdk.StatSpeed:          5,
+	}
+	evs := battle.Spread{
+		pokesdk.StatHP:             74,
+		pokesdk.StatAttack:         190,
+		pokesdk.StatDefense:        91,
+		pokesdk.StatSpecialAttack:  48,
+		pokesdk.StatSpecialDefense: 84,
+		pokesdk.StatSpeed:          23,
+	}
+
+	computed := battle.ComputeStats(garchomp, 78, ivs, evs, battle.Natures["adamant"])
+	expected := map[pokesdk.StatName]int{
+		pokesdk.StatHP:             289,
+		pokesdk.StatAttack:         278,
+		pokesdk.StatDefense:        193,
+		pokesdk.StatSpecialAttack:  135,
+		pokesdk.StatSpecialDefense: 171,
+		pokesdk.StatSpeed:          171,
+	}
+
+	if !reflect.DeepEqual(computed, expected) {
+		t.Errorf("unexpected stats: %v", computed)
+	}
+}
+
+func TestComputeStatsShedinja(t *testing.T) {
+	shedinja := &pokesdk.Pokemon{
+		Name:  "shedinja",
+		Stats: stats(map[pokesdk.StatName]int{pokesdk.StatHP: 1}),
+	}
+
+	computed := battle.ComputeStats(shedinja, 100, battle.MaxIVs, battle.Spread{pokesdk.StatHP: 252}, battle.Natures["hardy"])
+	if computed[pokesdk.StatHP] != 1 {
+		t.Errorf("expected 1 hp, got %d", computed[pokesdk.StatHP])
+	}
+}
+
+func TestNatures(t *testing.T) {
+	if len(battle.Natures) != 25 {
+		t.Fatalf("expected 25 natures, got %d", len(battle.Natures))
+	}
+
+	timid := battle.Natures["timid"]
+	if timid.Modifier(pokesdk.StatSpeed) != 1.1 || timid.Modifier(pokesdk.StatAttack) != 0.9 || timid.Modifier(pokesdk.StatDefense) != 1 {
+		t.Errorf("unexpected timid modifiers: %+v", timid)
+	}
+
+	if battle.Natures["serious"].Modifier(pokesdk.StatSpeed) != 1 {
+		t.Errorf("expected neutral nature")
+	}
+}
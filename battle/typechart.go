@@ -0,0 +1,120 @@
+// Package battle provides battle calculations built on Pokemon API data, such
+// as type effectiveness, stat computation and damage.
+//
+//	chart, err := battle.LoadTypeChart(ctx, sdk, "generation-ix")
+//	if err != nil {
+//		panic(err)
+//	}
+//	attacker := battle.NewBattler(pikachu, 50, "", battle.MaxIVs, nil, battle.Natures["timid"])
+//	defender := battle.NewBattler(gyarados, 50, "", battle.MaxIVs, nil, battle.Natures["adamant"])
+//	low, high := chart.DamageRange(attacker, defender, battle.Move{
+//		Type:     pokesdk.TypeElectric,
+//		Power:    90,
+//		Category: battle.Special,
+//	})
+package battle
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/danielgtaylor/pokesdk"
+)
+
+// TypeChart maps an attacking type to the damage multiplier against each
+// defending type. Missing entries are neutral (1x) matchups.
+type TypeChart map[pokesdk.TypeName]map[pokesdk.TypeName]float64
+
+// NewTypeChart builds a type chart from type resources as they were in the
+// given generation, e.g. `generation-v`. Pass an empty generation to use the
+// current damage relations. Types introduced after the generation are left
+// out of the chart.
+func NewTypeChart(types []*pokesdk.Type, generation string) TypeChart {
+	gen := pokesdk.GenerationNumber(generation)
+	chart := TypeChart{}
+
+	for _, t := range types {
+		if gen > 0 && pokesdk.GenerationNumber(t.Generation.Name) > gen {
+			continue
+		}
+
+		relations := relationsInGeneration(t, gen)
+		multipliers := map[pokesdk.TypeName]float64{}
+		for _, link := range relations.DoubleDamageTo {
+			multipliers[link.Name] = 2
+		}
+		for _, link := range relations.HalfDamageTo {
+			multipliers[link.Name] = 0.5
+		}
+		for _, link := range relations.NoDamageTo {
+			multipliers[link.Name] = 0
+		}
+		chart[t.Name] = multipliers
+	}
+
+	return chart
+}
+
+// relationsInGeneration returns the damage relations for a type in a given
+// generation number. Past relations apply to every generation up to and
+// including the one they list, so the earliest one at or after `gen` wins.
+func relationsInGeneration(t *pokesdk.Type, gen int) pokesdk.DamageRelations {
+	if gen == 0 {
+		return t.DamageRelations
+	}
+
+	relations := t.DamageRelations
+	best := 0
+	for _, past := range t.PastDamageRelations {
+		n := pokesdk.GenerationNumber(past.Generation.Name)
+		if n >= gen && (best == 0 || n < best) {
+			best = n
+			relations = past.DamageRelations
+		}
+	}
+	return relations
+}
+
+// LoadTypeChart fetches all types from the API and builds a type chart for
+// the given generation. See `NewTypeChart` for details.
+func LoadTypeChart(ctx context.Context, sdk *pokesdk.SDK, generation string) (TypeChart, error) {
+	types := []*pokesdk.Type{}
+	for result := range sdk.ListTypes().All(ctx) {
+		if result.Error != nil {
+			return nil, fmt.Errorf("failed to list types: %w", result.Error)
+		}
+
+		t, err := sdk.GetType(ctx, result.Value.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get type %s: %w", result.Value.Name, err)
+		}
+		types = append(types, t)
+	}
+
+	return NewTypeChart(types, generation), nil
+}
+
+// Effectiveness returns the damage multiplier of an attacking type against
+// one or more defending types, e.g. 4 for electric against water/flying.
+//
+//	chart.Effectiveness(pokesdk.TypeElectric, pokesdk.TypeWater, pokesdk.TypeFlying)
+func (c TypeChart) Effectiveness(attack pokesdk.TypeName, defend ...pokesdk.TypeName) float64 {
+	multiplier := 1.0
+	for _, d := range defend {
+		if m, ok := c[attack][d]; ok {
+			multiplier *= m
+		}
+	}
+	return multiplier
+}
+
+// EffectivenessAgainst returns the damage multiplier of an attacking type
+// against a Pokemon's types as they were in the given generation.
+func (c TypeChart) EffectivenessAgainst(attack pokesdk.TypeName, p *pokesdk.Pokemon, generation string) float64 {
+	return c.Effectiveness(attack, typeNames(p.TypesInGeneration(generation))...)
+}
+
+func typeNames(types []pokesdk.Types) []pokesdk.TypeName {
+	p := pokesdk.Pokemon{Types: types}
+	return p.TypeNames()
+}
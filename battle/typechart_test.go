@@ -0,0 +1,111 @@
+package battle_test
+
+import (
+	"testing"
+
+	"github.com/danielgtaylor/pokesdk"
+	"github.com/danielgtaylor/pokesdk/battle"
+)
+
+func links(names ...pokesdk.TypeName) []pokesdk.TypeLink {
+	l := make([]pokesdk.TypeLink, 0, len(names))
+	for _, n := range names {
+		l = append(l, pokesdk.TypeLink{Name: n})
+	}
+	return l
+}
+
+// testTypes is a small subset of the real type chart used for offline tests.
+var testTypes = []*pokesdk.Type{
+	{
+		Name:       pokesdk.TypeElectric,
+		Generation: pokesdk.NamedLink{Name: "generation-i"},
+		DamageRelations: pokesdk.DamageRelations{
+			DoubleDamageTo: links(pokesdk.TypeWater, pokesdk.TypeFlying),
+			HalfDamageTo:   links(pokesdk.TypeElectric, pokesdk.TypeGrass, pokesdk.TypeDragon),
+			NoDamageTo:     links(pokesdk.TypeGround),
+		},
+	},
+	{
+		Name:       pokesdk.TypeGhost,
+		Generation: pokesdk.NamedLink{Name: "generation-i"},
+		DamageRelations: pokesdk.DamageRelations{
+			DoubleDamageTo: links(pokesdk.TypeGhost, pokesdk.TypePsychic),
+			HalfDamageTo:   links(pokesdk.TypeDark),
+			NoDamageTo:     links(pokesdk.TypeNormal),
+		},
+		PastDamageRelations: []pokesdk.PastDamageRelations{
+			{
+				Generation: pokesdk.NamedLink{Name: "generation-i"},
+				DamageRelations: pokesdk.DamageRelations{
+					DoubleDamageTo: links(pokesdk.TypeGhost),
+					NoDamageTo:     links(pokesdk.TypeNormal, pokesdk.TypePsychic),
+				},
+			},
+		},
+	},
+	{
+		Name:       pokesdk.TypeFairy,
+		Generation: pokesdk.NamedLink{Name: "generation-vi"},
+		DamageRelations: pokesdk.DamageRelations{
+			DoubleDamageTo: links(pokesdk.TypeDragon),
+		},
+	},
+}
+
+func TestEffectiveness(t *testing.T) {
+	chart := battle.NewTypeChart(testTypes, "")
+
+	for _, tc := range []struct {
+		attack   pokesdk.TypeName
+		defend   []pokesdk.TypeName
+		expected float64
+	}{
+		{pokesdk.TypeElectric, []pokesdk.TypeName{pokesdk.TypeWater}, 2},
+		{pokesdk.TypeElectric, []pokesdk.TypeName{pokesdk.TypeWater, pokesdk.TypeFlying}, 4},
+		{pokesdk.TypeElectric, []pokesdk.TypeName{pokesdk.TypeWater, pokesdk.TypeGround}, 0},
+		{pokesdk.TypeElectric, []pokesdk.TypeName{pokesdk.TypeGrass, pokesdk.TypeDragon}, 0.25},
+		{pokesdk.TypeElectric, []pokesdk.TypeName{pokesdk.TypeFire}, 1},
+		{pokesdk.TypeGhost, []pokesdk.TypeName{pokesdk.TypePsychic}, 2},
+		{pokesdk.TypeFairy, []pokesdk.TypeName{pokesdk.TypeDragon}, 2},
+	} {
+		if m := chart.Effectiveness(tc.attack, tc.defend...); m != tc.expected {
+			t.Errorf("%s vs %v: expected %v, got %v", tc.attack, tc.defend, tc.expected, m)
+		}
+	}
+}
+
+func TestEffectivenessGeneration(t *testing.T) {
+	chart := battle.NewTypeChart(testTypes, "generation-i")
+
+	// Ghost famously did not affect Psychic in generation I.
+	if m := chart.Effectiveness(pokesdk.TypeGhost, pokesdk.TypePsychic); m != 0 {
+		t.Errorf("expected no effect, got %v", m)
+	}
+
+	if _, ok := chart[pokesdk.TypeFairy]; ok {
+		t.Errorf("expected fairy to be excluded from generation I")
+	}
+}
+
+func TestEffectivenessAgainst(t *testing.T) {
+	chart := battle.NewTypeChart(testTypes, "")
+
+	clefairy := &pokesdk.Pokemon{
+		Types: []pokesdk.Types{{Slot: 1, Type: pokesdk.TypeLink{Name: pokesdk.TypeFairy}}},
+		PastTypes: []pokesdk.PastTypes{
+			{
+				Generation: pokesdk.NamedLink{Name: "generation-v"},
+				Types:      []pokesdk.Types{{Slot: 1, Type: pokesdk.TypeLink{Name: pokesdk.TypeNormal}}},
+			},
+		},
+	}
+
+	if m := chart.EffectivenessAgainst(pokesdk.TypeGhost, clefairy, "generation-v"); m != 0 {
+		t.Errorf("expected ghost to not affect normal-type clefairy, got %v", m)
+	}
+
+	if m := chart.EffectivenessAgainst(pokesdk.TypeGhost, clefairy, "generation-vi"); m != 1 {
+		t.Errorf("expected neutral damage against fairy-type clefairy, got %v", m)
+	}
+}
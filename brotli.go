@@ -0,0 +1,1116 @@
+package pokesdk
+
+import (
+	"bufio"
+	_ "embed"
+	"errors"
+	"io"
+)
+
+// BrotliDecompressor decompresses `br` encoded responses as described in
+// RFC 7932. It is registered by default.
+func BrotliDecompressor(r io.Reader) (io.ReadCloser, error) {
+	d := &brotliReader{}
+	d.bits.r = bufio.NewReader(r)
+	if err := d.readWindow(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// brotliChunk is roughly how much output is decoded at a time, so that memory
+// use is bounded by the window rather than by the size of a meta-block.
+const brotliChunk = 64 << 10
+
+func brotliError(what string) error {
+	return errors.New("invalid brotli data: " + what)
+}
+
+// brotliBits reads a brotli stream least significant bit first.
+type brotliBits struct {
+	r   io.ByteReader
+	val uint64
+	n   uint
+	eof bool
+	err error
+}
+
+// fill buffers at least `n` bits, up to 56, unless the input ends first.
+func (b *brotliBits) fill(n uint) {
+	for b.n < n && !b.eof {
+		c, err := b.r.ReadByte()
+		if err != nil {
+			b.eof = true
+			if err != io.EOF {
+				b.err = err
+			}
+			break
+		}
+		b.val |= uint64(c) << b.n
+		b.n += 8
+	}
+}
+
+// peek returns the next `n` bits without consuming them. Bits past the end of
+// the input are zero.
+func (b *brotliBits) peek(n uint) uint32 {
+	b.fill(n)
+	return uint32(b.val & (1<<n - 1))
+}
+
+func (b *brotliBits) skip(n uint) {
+	if n > b.n {
+		if b.err == nil {
+			b.err = io.ErrUnexpectedEOF
+		}
+		b.val, b.n = 0, 0
+		return
+	}
+	b.val >>= n
+	b.n -= n
+}
+
+func (b *brotliBits) read(n uint) int {
+	v := b.peek(n)
+	b.skip(n)
+	return int(v)
+}
+
+// align skips to the next byte boundary. The skipped bits must be zero.
+func (b *brotliBits) align() {
+	if b.read(b.n%8) != 0 && b.err == nil {
+		b.err = brotliError("non-zero padding")
+	}
+}
+
+// readByte reads a byte once the reader is aligned.
+func (b *brotliBits) readByte() byte {
+	if b.n >= 8 {
+		return byte(b.read(8))
+	}
+	c, err := b.r.ReadByte()
+	if err != nil && b.err == nil {
+		b.err = unexpected(err)
+	}
+	return c
+}
+
+// readCount reads a number from 1 to 256, as used for the number of block
+// types and prefix trees.
+func (b *brotliBits) readCount() int {
+	if b.read(1) == 0 {
+		return 1
+	}
+	n := uint(b.read(3))
+	return b.read(n) + 1<<n + 1
+}
+
+// brotliCode is a canonical prefix code.
+type brotliCode struct {
+	// single is the only symbol of a code which uses no bits, or -1.
+	single int
+
+	// lookup maps the next eight bits to a symbol and its length, for codes
+	// of up to eight bits. Longer codes are decoded bit by bit via counts.
+	lookup  [256]uint16
+	counts  [16]uint16
+	symbols []uint16
+}
+
+// build sets up the code from the code length of each symbol.
+func (c *brotliCode) build(lengths []uint8) {
+	c.single = -1
+	c.counts = [16]uint16{}
+	for _, l := range lengths {
+		c.counts[l]++
+	}
+	c.counts[0] = 0
+
+	var offsets, next [16]int
+	code := 0
+	for l := 1; l < 16; l++ {
+		offsets[l] = offsets[l-1] + int(c.counts[l-1])
+		code = (code + int(c.counts[l-1])) << 1
+		next[l] = code
+	}
+
+	c.symbols = make([]uint16, offsets[15]+int(c.counts[15]))
+	c.lookup = [256]uint16{}
+	for sym, l := range lengths {
+		if l == 0 {
+			continue
+		}
+		c.symbols[offsets[l]] = uint16(sym)
+		offsets[l]++
+
+		code := next[l]
+		next[l]++
+		if l <= 8 {
+			rev := 0
+			for i := uint8(0); i < l; i++ {
+				rev |= (code >> i & 1) << (l - 1 - i)
+			}
+			for i := rev; i < 256; i += 1 << l {
+				c.lookup[i] = uint16(sym)<<4 | uint16(l)
+			}
+		}
+	}
+}
+
+// decode reads a symbol.
+func (c *brotliCode) decode(b *brotliBits) int {
+	if c.single >= 0 {
+		return c.single
+	}
+	bits := b.peek(15)
+	if e := c.lookup[bits&0xff]; e != 0 {
+		b.skip(uint(e & 15))
+		return int(e >> 4)
+	}
+
+	code, first, index := 0, 0, 0
+	for l := uint(1); l < 16; l++ {
+		code |= int(bits>>(l-1)) & 1
+		count := int(c.counts[l])
+		if code-first < count {
+			b.skip(l)
+			return int(c.symbols[index+code-first])
+		}
+		index += count
+		first = (first + count) << 1
+		code <<= 1
+	}
+	if b.err == nil {
+		b.err = brotliError("invalid prefix code")
+	}
+	return 0
+}
+
+// brotliCategory tracks the block type and count for literals, commands or
+// distances.
+type brotliCategory struct {
+	types             int
+	typeCode          brotliCode
+	countCode         brotliCode
+	current, previous int
+	remaining         int
+}
+
+var (
+	brotliBlockLengthBase  = [26]int{1, 5, 9, 13, 17, 25, 33, 41, 49, 65, 81, 97, 113, 145, 177, 209, 241, 305, 369, 497, 753, 1265, 2289, 4337, 8433, 16625}
+	brotliBlockLengthExtra = [26]uint{2, 2, 2, 2, 3, 3, 3, 3, 4, 4, 4, 4, 5, 5, 5, 5, 6, 6, 7, 8, 9, 10, 11, 12, 13, 24}
+
+	brotliInsertBase  = [24]int{0, 1, 2, 3, 4, 5, 6, 8, 10, 14, 18, 26, 34, 50, 66, 98, 130, 194, 322, 578, 1090, 2114, 6210, 22594}
+	brotliInsertExtra = [24]uint{0, 0, 0, 0, 0, 0, 1, 1, 2, 2, 3, 3, 4, 4, 5, 5, 6, 7, 8, 9, 10, 12, 14, 24}
+	brotliCopyBase    = [24]int{2, 3, 4, 5, 6, 7, 8, 9, 10, 12, 14, 18, 22, 30, 38, 54, 70, 102, 134, 198, 326, 582, 1094, 2118}
+	brotliCopyExtra   = [24]uint{0, 0, 0, 0, 0, 0, 0, 0, 1, 1, 2, 2, 3, 3, 4, 4, 5, 5, 6, 7, 8, 9, 10, 24}
+
+	// brotliCommandCells maps the top bits of a command code to the first
+	// insert and copy length codes of its cell.
+	brotliCommandCells = [11][2]int{{0, 0}, {0, 8}, {0, 0}, {0, 8}, {8, 0}, {8, 8}, {0, 16}, {16, 0}, {8, 16}, {16, 8}, {16, 16}}
+
+	brotliCodeLengthOrder = [18]int{1, 2, 3, 4, 0, 5, 17, 6, 16, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+
+	// The fixed code for code length code lengths, indexed by the next four
+	// bits.
+	brotliCodeLengthLength = [16]uint{2, 2, 2, 3, 2, 2, 2, 4, 2, 2, 2, 3, 2, 2, 2, 4}
+	brotliCodeLengthValue  = [16]uint8{0, 4, 3, 2, 0, 4, 3, 1, 0, 4, 3, 2, 0, 4, 3, 5}
+)
+
+// brotliReader decodes a brotli stream.
+type brotliReader struct {
+	bits   brotliBits
+	window int
+	err    error
+
+	// hist holds the output, of which the last `window` bytes are kept for
+	// back-references and `hist[out:]` is yet to be read.
+	hist  []byte
+	out   int
+	total int
+
+	state     int
+	last      bool
+	remaining int
+
+	literal, command, distance brotliCategory
+	contextModes               []byte
+	literalMap, distanceMap    []byte
+	literalCodes               []brotliCode
+	commandCodes               []brotliCode
+	distanceCodes              []brotliCode
+	postfix                    uint
+	direct                     int
+
+	dist    [4]int
+	distIdx int
+
+	// The rest of the current command.
+	insert   int
+	copyLen  int
+	copyDist int
+	implicit bool
+}
+
+const (
+	brotliHeader = iota
+	brotliUncompressed
+	brotliMetadata
+	brotliInsert
+	brotliCommand
+	brotliCopy
+	brotliDone
+)
+
+func (d *brotliReader) Read(p []byte) (int, error) {
+	for d.out == len(d.hist) {
+		if d.err != nil {
+			return 0, d.err
+		}
+		d.decode()
+	}
+	n := copy(p, d.hist[d.out:])
+	d.out += n
+	return n, nil
+}
+
+func (d *brotliReader) Close() error {
+	return nil
+}
+
+// readWindow reads the stream header with the window size.
+func (d *brotliReader) readWindow() error {
+	b := &d.bits
+	bits := 16
+	if b.read(1) == 1 {
+		if n := b.read(3); n != 0 {
+			bits = 17 + n
+		} else if n := b.read(3); n == 1 {
+			return brotliError("large windows are not supported")
+		} else if n == 0 {
+			bits = 17
+		} else {
+			bits = 8 + n
+		}
+	}
+	if b.err != nil {
+		return b.err
+	}
+	d.window = 1<<bits - 16
+	d.dist = [4]int{16, 15, 11, 4}
+	return nil
+}
+
+// decode decodes up to about `brotliChunk` bytes, or sets `err`.
+func (d *brotliReader) decode() {
+	if len(d.hist) > 2*d.window+brotliChunk {
+		n := copy(d.hist, d.hist[len(d.hist)-d.window:])
+		d.hist = d.hist[:n]
+		d.out = n
+	}
+
+	for len(d.hist)-d.out < brotliChunk && d.err == nil {
+		switch d.state {
+		case brotliHeader:
+			d.readHeader()
+		case brotliUncompressed:
+			for ; d.remaining > 0 && len(d.hist)-d.out < brotliChunk; d.remaining-- {
+				c := d.bits.readByte()
+				if d.bits.err != nil {
+					break
+				}
+				d.emit(c)
+			}
+			if d.remaining == 0 {
+				d.endMetaBlock()
+			}
+		case brotliMetadata:
+			for ; d.remaining > 0 && d.bits.err == nil; d.remaining-- {
+				d.bits.readByte()
+			}
+			d.endMetaBlock()
+		case brotliCommand:
+			d.readCommand()
+		case brotliInsert:
+			d.readLiterals()
+		case brotliCopy:
+			d.copy()
+		case brotliDone:
+			d.err = io.EOF
+		}
+		if d.bits.err != nil {
+			// Running out of input explains any other error.
+			d.err = d.bits.err
+		}
+	}
+}
+
+func (d *brotliReader) emit(c byte) {
+	d.hist = append(d.hist, c)
+	d.total++
+}
+
+// endMetaBlock moves on to the next meta-block, or ends the stream.
+func (d *brotliReader) endMetaBlock() {
+	if !d.last {
+		d.state = brotliHeader
+		return
+	}
+	d.bits.align()
+	if d.bits.n > 0 {
+		d.err = brotliError("data after end of stream")
+	} else if _, err := d.bits.r.ReadByte(); err == nil {
+		d.err = brotliError("data after end of stream")
+	}
+	d.state = brotliDone
+}
+
+// readHeader reads a meta-block header along with its prefix codes.
+func (d *brotliReader) readHeader() {
+	b := &d.bits
+	d.last = b.read(1) == 1
+	if d.last && b.read(1) == 1 {
+		d.remaining = 0
+		d.endMetaBlock()
+		return
+	}
+
+	nibbles := [4]int{4, 5, 6, 0}[b.read(2)]
+	if nibbles == 0 {
+		if b.read(1) != 0 {
+			d.err = brotliError("reserved bit set")
+			return
+		}
+		n := b.read(2)
+		d.remaining = 0
+		for i := 0; i < n; i++ {
+			v := b.read(8)
+			if i == n-1 && n > 1 && v == 0 {
+				d.err = brotliError("invalid metadata length")
+				return
+			}
+			d.remaining |= v << (8 * i)
+		}
+		if n > 0 {
+			d.remaining++
+		}
+		b.align()
+		d.state = brotliMetadata
+		return
+	}
+
+	d.remaining = 0
+	for i := 0; i < nibbles; i++ {
+		v := b.read(4)
+		if i == nibbles-1 && nibbles > 4 && v == 0 {
+			d.err = brotliError("invalid meta-block length")
+			return
+		}
+		d.remaining |= v << (4 * i)
+	}
+	d.remaining++
+
+	if !d.last && b.read(1) == 1 {
+		b.align()
+		d.state = brotliUncompressed
+		return
+	}
+
+	for _, c := range []*brotliCategory{&d.literal, &d.command, &d.distance} {
+		*c = brotliCategory{types: b.readCount(), previous: 1, remaining: 1 << 28}
+		if c.types >= 2 {
+			if d.readCode(&c.typeCode, c.types+2) != nil || d.readCode(&c.countCode, 26) != nil {
+				return
+			}
+			c.remaining = d.blockLength(&c.countCode)
+		}
+	}
+
+	d.postfix = uint(b.read(2))
+	d.direct = b.read(4) << d.postfix
+
+	d.contextModes = make([]byte, d.literal.types)
+	for i := range d.contextModes {
+		d.contextModes[i] = byte(b.read(2))
+	}
+
+	var literalTrees, distanceTrees int
+	if d.literalMap, literalTrees = d.readContextMap(64 * d.literal.types); d.err != nil {
+		return
+	}
+	if d.distanceMap, distanceTrees = d.readContextMap(4 * d.distance.types); d.err != nil {
+		return
+	}
+
+	d.literalCodes = d.readCodes(literalTrees, 256)
+	d.commandCodes = d.readCodes(d.command.types, 704)
+	d.distanceCodes = d.readCodes(distanceTrees, 16+d.direct+48<<d.postfix)
+	d.state = brotliCommand
+}
+
+// blockLength reads a block count.
+func (d *brotliReader) blockLength(code *brotliCode) int {
+	sym := code.decode(&d.bits)
+	return brotliBlockLengthBase[sym] + d.bits.read(brotliBlockLengthExtra[sym])
+}
+
+// switchBlock reads a block switch command.
+func (d *brotliReader) switchBlock(c *brotliCategory) {
+	t := c.typeCode.decode(&d.bits)
+	switch t {
+	case 0:
+		t = c.previous
+	case 1:
+		t = c.current + 1
+	default:
+		t -= 2
+	}
+	if t >= c.types {
+		t -= c.types
+	}
+	c.previous, c.current = c.current, t
+	c.remaining = d.blockLength(&c.countCode)
+}
+
+func (d *brotliReader) readCodes(n, alphabet int) []brotliCode {
+	codes := make([]brotliCode, n)
+	for i := range codes {
+		if d.readCode(&codes[i], alphabet) != nil {
+			break
+		}
+	}
+	return codes
+}
+
+// readCode reads a prefix code for the given alphabet size.
+func (d *brotliReader) readCode(c *brotliCode, alphabet int) error {
+	b := &d.bits
+	hskip := b.read(2)
+	if hskip == 1 {
+		d.readSimpleCode(c, alphabet)
+		return d.err
+	}
+
+	// The code lengths are themselves prefix coded.
+	var lengths [18]uint8
+	space, nonZero := 32, 0
+	for i := hskip; i < 18 && space > 0; i++ {
+		v := b.peek(4)
+		b.skip(brotliCodeLengthLength[v])
+		l := brotliCodeLengthValue[v]
+		lengths[brotliCodeLengthOrder[i]] = l
+		if l != 0 {
+			space -= 32 >> l
+			nonZero++
+		}
+	}
+	if nonZero != 1 && space != 0 {
+		d.err = brotliError("invalid code length code")
+		return d.err
+	}
+	lengthCode := &brotliCode{}
+	lengthCode.build(lengths[:])
+	if nonZero == 1 {
+		for sym, l := range lengths {
+			if l != 0 {
+				lengthCode.single = sym
+			}
+		}
+	}
+
+	symbols := make([]uint8, alphabet)
+	prev, repeatLen := uint8(8), uint8(0)
+	repeat := 0
+	space = 32768
+	for sym := 0; sym < alphabet && space > 0 && b.err == nil; {
+		l := lengthCode.decode(b)
+		if l < 16 {
+			repeat = 0
+			symbols[sym] = uint8(l)
+			sym++
+			if l != 0 {
+				prev = uint8(l)
+				space -= 32768 >> l
+			}
+			continue
+		}
+
+		extra, newLen := uint(2), prev
+		if l == 17 {
+			extra, newLen = 3, 0
+		}
+		if repeatLen != newLen {
+			repeat, repeatLen = 0, newLen
+		}
+		old := repeat
+		if repeat > 0 {
+			repeat = (repeat - 2) << extra
+		}
+		repeat += b.read(extra) + 3
+		n := repeat - old
+		if sym+n > alphabet {
+			d.err = brotliError("code length repeat overflows alphabet")
+			return d.err
+		}
+		for i := 0; i < n; i++ {
+			symbols[sym] = newLen
+			sym++
+		}
+		if newLen != 0 {
+			space -= n << (15 - newLen)
+		}
+	}
+	if space != 0 && b.err == nil {
+		d.err = brotliError("invalid code lengths")
+		return d.err
+	}
+	c.build(symbols)
+	return b.err
+}
+
+// readSimpleCode reads a prefix code with up to four symbols.
+func (d *brotliReader) readSimpleCode(c *brotliCode, alphabet int) {
+	b := &d.bits
+	bits := uint(0)
+	for 1<<bits < alphabet {
+		bits++
+	}
+
+	n := b.read(2) + 1
+	var symbols [4]int
+	for i := 0; i < n; i++ {
+		symbols[i] = b.read(bits)
+		if symbols[i] >= alphabet {
+			d.err = brotliError("symbol outside alphabet")
+			return
+		}
+		for j := 0; j < i; j++ {
+			if symbols[j] == symbols[i] {
+				d.err = brotliError("duplicate symbol")
+				return
+			}
+		}
+	}
+
+	var lengths []uint8
+	switch n {
+	case 1:
+		c.build(nil)
+		c.single = symbols[0]
+		return
+	case 2:
+		lengths = []uint8{1, 1}
+	case 3:
+		lengths = []uint8{1, 2, 2}
+	default:
+		lengths = []uint8{2, 2, 2, 2}
+		if b.read(1) == 1 {
+			lengths = []uint8{1, 2, 3, 3}
+		}
+	}
+
+	largest := 0
+	for _, s := range symbols[:n] {
+		largest = max(largest, s)
+	}
+	all := make([]uint8, largest+1)
+	for i, s := range symbols[:n] {
+		all[s] = lengths[i]
+	}
+	c.build(all)
+}
+
+// readContextMap reads a context map of the given size, and returns it with
+// the number of prefix trees it refers to.
+func (d *brotliReader) readContextMap(size int) ([]byte, int) {
+	b := &d.bits
+	m := make([]byte, size)
+	trees := b.readCount()
+	if trees < 2 {
+		return m, trees
+	}
+
+	rleMax := 0
+	if b.read(1) == 1 {
+		rleMax = b.read(4) + 1
+	}
+	code := &brotliCode{}
+	if d.readCode(code, trees+rleMax) != nil {
+		return nil, 0
+	}
+	for i := 0; i < size && b.err == nil; {
+		sym := code.decode(b)
+		switch {
+		case sym == 0:
+			i++
+		case sym <= rleMax:
+			n := 1<<sym + b.read(uint(sym))
+			if i+n > size {
+				d.err = brotliError("context map run overflows")
+				return nil, 0
+			}
+			i += n
+		default:
+			m[i] = byte(sym - rleMax)
+			i++
+		}
+	}
+
+	if b.read(1) == 1 {
+		// Inverse move-to-front transform.
+		var mtf [256]byte
+		for i := range mtf {
+			mtf[i] = byte(i)
+		}
+		for i, idx := range m {
+			v := mtf[idx]
+			copy(mtf[1:idx+1], mtf[:idx])
+			mtf[0] = v
+			m[i] = v
+		}
+	}
+	return m, trees
+}
+
+// readCommand reads an insert-and-copy command.
+func (d *brotliReader) readCommand() {
+	if d.remaining == 0 {
+		d.endMetaBlock()
+		return
+	}
+	b := &d.bits
+	if d.command.remaining == 0 {
+		d.switchBlock(&d.command)
+	}
+	d.command.remaining--
+
+	code := d.commandCodes[d.command.current].decode(b)
+	cell := brotliCommandCells[code>>6]
+	insertCode := cell[0] + code>>3&7
+	copyCode := cell[1] + code&7
+	d.insert = brotliInsertBase[insertCode] + b.read(brotliInsertExtra[insertCode])
+	d.copyLen = brotliCopyBase[copyCode] + b.read(brotliCopyExtra[copyCode])
+	d.implicit = code < 128
+	if d.insert > d.remaining {
+		d.err = brotliError("insert length exceeds meta-block")
+		return
+	}
+	d.state = brotliInsert
+}
+
+// readLiterals emits the literals of the current command.
+func (d *brotliReader) readLiterals() {
+	b := &d.bits
+	for ; d.insert > 0 && len(d.hist)-d.out < brotliChunk; d.insert-- {
+		if d.literal.remaining == 0 {
+			d.switchBlock(&d.literal)
+		}
+		d.literal.remaining--
+
+		var p1, p2 byte
+		if n := len(d.hist); n > 1 {
+			p1, p2 = d.hist[n-1], d.hist[n-2]
+		} else if n == 1 {
+			p1 = d.hist[0]
+		}
+		ctx := brotliContext(d.contextModes[d.literal.current], p1, p2)
+		tree := d.literalMap[64*d.literal.current+int(ctx)]
+		d.emit(byte(d.literalCodes[tree].decode(b)))
+		d.remaining--
+		if b.err != nil {
+			return
+		}
+	}
+	if d.insert > 0 {
+		return
+	}
+	if d.remaining == 0 {
+		d.endMetaBlock()
+		return
+	}
+	d.readDistance()
+}
+
+// readDistance reads the distance of the current command and starts copying.
+func (d *brotliReader) readDistance() {
+	b := &d.bits
+	code := 0
+	if !d.implicit {
+		if d.distance.remaining == 0 {
+			d.switchBlock(&d.distance)
+		}
+		d.distance.remaining--
+		ctx := min(d.copyLen, 5) - 2
+		code = d.distanceCodes[d.distanceMap[4*d.distance.current+ctx]].decode(b)
+	}
+
+	var dist int
+	switch {
+	case code < 16:
+		k := 1
+		if code < 4 {
+			k = code + 1
+		} else if code >= 10 {
+			k = 2
+		}
+		dist = d.dist[(d.distIdx-k)&3]
+		if code >= 4 {
+			dist += [6]int{-1, 1, -2, 2, -3, 3}[(code-4)%6]
+		}
+		if dist <= 0 {
+			d.err = brotliError("invalid distance")
+			return
+		}
+	case code < 16+d.direct:
+		dist = code - 15
+	default:
+		c := code - 16 - d.direct
+		nbits := uint(1 + c>>(d.postfix+1))
+		high := c >> d.postfix
+		low := c & (1<<d.postfix - 1)
+		offset := (2+high&1)<<nbits - 4
+		dist = (offset+b.read(nbits))<<d.postfix + low + d.direct + 1
+	}
+
+	maxDist := min(d.window, d.total)
+	if dist > maxDist {
+		d.dictionaryWord(dist - maxDist - 1)
+		return
+	}
+	if code != 0 {
+		d.dist[d.distIdx&3] = dist
+		d.distIdx++
+	}
+	if d.copyLen > d.remaining {
+		d.err = brotliError("copy length exceeds meta-block")
+		return
+	}
+	d.copyDist = dist
+	d.state = brotliCopy
+}
+
+// copy emits the back-reference of the current command.
+func (d *brotliReader) copy() {
+	for d.copyLen > 0 && len(d.hist)-d.out < brotliChunk {
+		start := len(d.hist) - d.copyDist
+		n := min(d.copyLen, d.copyDist, brotliChunk)
+		d.hist = append(d.hist, d.hist[start:start+n]...)
+		d.total += n
+		d.copyLen -= n
+		d.remaining -= n
+	}
+	if d.copyLen == 0 {
+		d.state = brotliCommand
+	}
+}
+
+// dictionaryWord emits a word from the static dictionary.
+func (d *brotliReader) dictionaryWord(id int) {
+	if d.copyLen < 4 || d.copyLen > 24 {
+		d.err = brotliError("invalid dictionary reference")
+		return
+	}
+	bits := brotliDictionaryBits[d.copyLen]
+	index, transform := id&(1<<bits-1), id>>bits
+	if transform >= len(brotliTransforms) {
+		d.err = brotliError("invalid dictionary transform")
+		return
+	}
+
+	offset := brotliDictionaryOffsets[d.copyLen] + index*d.copyLen
+	word := brotliDictionary[offset : offset+d.copyLen]
+	n := len(d.hist)
+	d.hist = brotliTransforms[transform].apply(d.hist, word)
+	d.total += len(d.hist) - n
+	if d.remaining -= len(d.hist) - n; d.remaining < 0 {
+		d.err = brotliError("dictionary word exceeds meta-block")
+		return
+	}
+	d.state = brotliCommand
+}
+
+// brotliContext returns the context ID of a literal from the previous two
+// bytes and the context mode of its block type.
+func brotliContext(mode, p1, p2 byte) byte {
+	switch mode {
+	case 0:
+		return p1 & 0x3f
+	case 1:
+		return p1 >> 2
+	case 2:
+		ctx := brotliUTF8Context1(p2)
+		if p1 < 128 {
+			return brotliUTF8Context0[p1] | ctx
+		}
+		return p1>>6&1<<1 | p1&1 | ctx
+	}
+	return brotliSignedContext(p1)<<3 | brotliSignedContext(p2)
+}
+
+// brotliUTF8Context0 is the UTF-8 context of ASCII characters before a
+// literal.
+var brotliUTF8Context0 = [128]byte{
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 4, 4, 0, 0, 4, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	8, 12, 16, 12, 12, 20, 12, 16, 24, 28, 12, 12, 32, 12, 36, 12,
+	44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 32, 32, 24, 40, 28, 12,
+	12, 48, 52, 52, 52, 48, 52, 52, 52, 48, 52, 52, 52, 52, 52, 48,
+	52, 52, 52, 52, 52, 48, 52, 52, 52, 52, 52, 24, 12, 28, 12, 12,
+	12, 56, 60, 60, 60, 56, 60, 60, 60, 56, 60, 60, 60, 60, 60, 56,
+	60, 60, 60, 60, 60, 56, 60, 60, 60, 60, 60, 24, 12, 28, 12, 0,
+}
+
+// brotliUTF8Context1 is the UTF-8 context of the second byte before a
+// literal.
+func brotliUTF8Context1(c byte) byte {
+	switch {
+	case c <= 32 || (c >= 127 && c < 224):
+		return 0
+	case c >= 224:
+		return 2
+	case c >= 97 && c <= 122:
+		return 3
+	case (c >= 48 && c <= 57) || (c >= 65 && c <= 90):
+		return 2
+	}
+	return 1
+}
+
+// brotliSignedContext is the signed context of a byte.
+func brotliSignedContext(c byte) byte {
+	switch {
+	case c == 0:
+		return 0
+	case c < 16:
+		return 1
+	case c < 64:
+		return 2
+	case c < 128:
+		return 3
+	case c < 192:
+		return 4
+	case c < 240:
+		return 5
+	case c < 255:
+		return 6
+	}
+	return 7
+}
+
+// brotliDictionary is the static dictionary from RFC 7932, appendix A.
+//
+//go:embed brotli_dictionary.bin
+var brotliDictionary string
+
+// brotliDictionaryBits is the number of bits of a word index per word length.
+var brotliDictionaryBits = [25]uint{0, 0, 0, 0, 10, 10, 11, 11, 10, 10, 10, 10, 10, 9, 9, 8, 7, 7, 8, 7, 7, 6, 6, 5, 5}
+
+// brotliDictionaryOffsets is where the words of each length start.
+var brotliDictionaryOffsets = func() [25]int {
+	var offsets [25]int
+	for l := 5; l < 25; l++ {
+		offsets[l] = offsets[l-1] + (l-1)<<brotliDictionaryBits[l-1]
+	}
+	return offsets
+}()
+
+// Dictionary word transform types.
+const (
+	brotliIdentity = iota
+	brotliOmitLast1
+	brotliOmitLast2
+	brotliOmitLast3
+	brotliOmitLast4
+	brotliOmitLast5
+	brotliOmitLast6
+	brotliOmitLast7
+	brotliOmitLast8
+	brotliOmitLast9
+	brotliUppercaseFirst
+	brotliUppercaseAll
+	brotliOmitFirst1
+	brotliOmitFirst2
+	brotliOmitFirst3
+	brotliOmitFirst4
+	brotliOmitFirst5
+	brotliOmitFirst6
+	brotliOmitFirst7
+	brotliOmitFirst8
+	brotliOmitFirst9
+)
+
+type brotliTransform struct {
+	prefix string
+	kind   int
+	suffix string
+}
+
+// apply appends the transformed word to `dst`.
+func (t brotliTransform) apply(dst []byte, word string) []byte {
+	dst = append(dst, t.prefix...)
+	switch {
+	case t.kind <= brotliOmitLast9:
+		word = word[:max(len(word)-t.kind, 0)]
+	case t.kind >= brotliOmitFirst1:
+		word = word[min(t.kind-brotliOmitFirst1+1, len(word)):]
+	}
+
+	start := len(dst)
+	dst = append(dst, word...)
+	switch t.kind {
+	case brotliUppercaseFirst:
+		brotliUppercase(dst[start:])
+	case brotliUppercaseAll:
+		for i := start; i < len(dst); {
+			i += brotliUppercase(dst[i:])
+		}
+	}
+	return append(dst, t.suffix...)
+}
+
+// brotliUppercase upper-cases the first character of `p` the way RFC 7932
+// does, and returns its length.
+func brotliUppercase(p []byte) int {
+	switch {
+	case p[0] < 0xc0:
+		if p[0] >= 'a' && p[0] <= 'z' {
+			p[0] ^= 32
+		}
+		return 1
+	case p[0] < 0xe0:
+		if len(p) > 1 {
+			p[1] ^= 32
+		}
+		return min(2, len(p))
+	}
+	if len(p) > 2 {
+		p[2] ^= 5
+	}
+	return min(3, len(p))
+}
+
+// brotliTransforms are the dictionary word transforms from RFC 7932,
+// appendix B.
+var brotliTransforms = [121]brotliTransform{
+	{"", brotliIdentity, ""},
+	{"", brotliIdentity, " "},
+	{" ", brotliIdentity, " "},
+	{"", brotliOmitFirst1, ""},
+	{"", brotliUppercaseFirst, " "},
+	{"", brotliIdentity, " the "},
+	{" ", brotliIdentity, ""},
+	{"s ", brotliIdentity, " "},
+	{"", brotliIdentity, " of "},
+	{"", brotliUppercaseFirst, ""},
+	{"", brotliIdentity, " and "},
+	{"", brotliOmitFirst2, ""},
+	{"", brotliOmitLast1, ""},
+	{", ", brotliIdentity, " "},
+	{"", brotliIdentity, ", "},
+	{" ", brotliUppercaseFirst, " "},
+	{"", brotliIdentity, " in "},
+	{"", brotliIdentity, " to "},
+	{"e ", brotliIdentity, " "},
+	{"", brotliIdentity, "\""},
+	{"", brotliIdentity, "."},
+	{"", brotliIdentity, "\">"},
+	{"", brotliIdentity, "\n"},
+	{"", brotliOmitLast3, ""},
+	{"", brotliIdentity, "]"},
+	{"", brotliIdentity, " for "},
+	{"", brotliOmitFirst3, ""},
+	{"", brotliOmitLast2, ""},
+	{"", brotliIdentity, " a "},
+	{"", brotliIdentity, " that "},
+	{" ", brotliUppercaseFirst, ""},
+	{"", brotliIdentity, ". "},
+	{".", brotliIdentity, ""},
+	{" ", brotliIdentity, ", "},
+	{"", brotliOmitFirst4, ""},
+	{"", brotliIdentity, " with "},
+	{"", brotliIdentity, "'"},
+	{"", brotliIdentity, " from "},
+	{"", brotliIdentity, " by "},
+	{"", brotliOmitFirst5, ""},
+	{"", brotliOmitFirst6, ""},
+	{" the ", brotliIdentity, ""},
+	{"", brotliOmitLast4, ""},
+	{"", brotliIdentity, ". The "},
+	{"", brotliUppercaseAll, ""},
+	{"", brotliIdentity, " on "},
+	{"", brotliIdentity, " as "},
+	{"", brotliIdentity, " is "},
+	{"", brotliOmitLast7, ""},
+	{"", brotliOmitLast1, "ing "},
+	{"", brotliIdentity, "\n\t"},
+	{"", brotliIdentity, ":"},
+	{" ", brotliIdentity, ". "},
+	{"", brotliIdentity, "ed "},
+	{"", brotliOmitFirst9, ""},
+	{"", brotliOmitFirst7, ""},
+	{"", brotliOmitLast6, ""},
+	{"", brotliIdentity, "("},
+	{"", brotliUppercaseFirst, ", "},
+	{"", brotliOmitLast8, ""},
+	{"", brotliIdentity, " at "},
+	{"", brotliIdentity, "ly "},
+	{" the ", brotliIdentity, " of "},
+	{"", brotliOmitLast5, ""},
+	{"", brotliOmitLast9, ""},
+	{" ", brotliUppercaseFirst, ", "},
+	{"", brotliUppercaseFirst, "\""},
+	{".", brotliIdentity, "("},
+	{"", brotliUppercaseAll, " "},
+	{"", brotliUppercaseFirst, "\">"},
+	{"", brotliIdentity, "=\""},
+	{" ", brotliIdentity, "."},
+	{".com/", brotliIdentity, ""},
+	{" the ", brotliIdentity, " of the "},
+	{"", brotliUppercaseFirst, "'"},
+	{"", brotliIdentity, ". This "},
+	{"", brotliIdentity, ","},
+	{".", brotliIdentity, " "},
+	{"", brotliUppercaseFirst, "("},
+	{"", brotliUppercaseFirst, "."},
+	{"", brotliIdentity, " not "},
+	{" ", brotliIdentity, "=\""},
+	{"", brotliIdentity, "er "},
+	{" ", brotliUppercaseAll, " "},
+	{"", brotliIdentity, "al "},
+	{" ", brotliUppercaseAll, ""},
+	{"", brotliIdentity, "='"},
+	{"", brotliUppercaseAll, "\""},
+	{"", brotliUppercaseFirst, ". "},
+	{" ", brotliIdentity, "("},
+	{"", brotliIdentity, "ful "},
+	{" ", brotliUppercaseFirst, ". "},
+	{"", brotliIdentity, "ive "},
+	{"", brotliIdentity, "less "},
+	{"", brotliUppercaseAll, "'"},
+	{"", brotliIdentity, "est "},
+	{" ", brotliUppercaseFirst, "."},
+	{"", brotliUppercaseAll, "\">"},
+	{" ", brotliIdentity, "='"},
+	{"", brotliUppercaseFirst, ","},
+	{"", brotliIdentity, "ize "},
+	{"", brotliUppercaseAll, "."},
+	{"\u00a0", brotliIdentity, ""},
+	{" ", brotliIdentity, ","},
+	{"", brotliUppercaseFirst, "=\""},
+	{"", brotliUppercaseAll, "=\""},
+	{"", brotliIdentity, "ous "},
+	{"", brotliUppercaseAll, ", "},
+	{"", brotliUppercaseFirst, "='"},
+	{" ", brotliUppercaseFirst, ","},
+	{" ", brotliUppercaseAll, "=\""},
+	{" ", brotliUppercaseAll, ", "},
+	{"", brotliUppercaseAll, ","},
+	{"", brotliUppercaseAll, "("},
+	{"", brotliUppercaseAll, ". "},
+	{" ", brotliUppercaseAll, "."},
+	{"", brotliUppercaseAll, "='"},
+	{" ", brotliUppercaseAll, ". "},
+	{" ", brotliUppercaseFirst, "=\""},
+	{" ", brotliUppercaseAll, "='"},
+	{" ", brotliUppercaseFirst, "='"},
+}
@@ -0,0 +1,79 @@
+package pokesdk_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/danielgtaylor/pokesdk"
+)
+
+func TestBrotliDecompressor(t *testing.T) {
+	pikachu, err := os.ReadFile("testdata/pokemon-pikachu.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		file     string
+		data     string
+		expected []byte
+	}{
+		{name: "dictionary and context modeling", file: "testdata/pokemon-pikachu.json.br", expected: pikachu},
+		{name: "sliding window", file: "testdata/pokemon-list.ndjson.br", expected: pokemonList()},
+		{name: "empty", data: "\x06", expected: []byte{}},
+		{name: "uncompressed", data: "\x10\x00\x10hi\x03", expected: []byte("hi")},
+		{name: "metadata", data: "\x2c\x01abc\x08\x00\x08hi\x03", expected: []byte("hi")},
+	}
+
+	for _, test := range tests {
+		data := []byte(test.data)
+		if test.file != "" {
+			if data, err = os.ReadFile(test.file); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		actual, err := decompress(pokesdk.BrotliDecompressor, data)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if !bytes.Equal(actual, test.expected) {
+			t.Errorf("%s: expected %d bytes, got %d", test.name, len(test.expected), len(actual))
+		}
+	}
+}
+
+func TestBrotliDecompressorErrors(t *testing.T) {
+	pikachu, err := os.ReadFile("testdata/pokemon-pikachu.json.br")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	corrupt := bytes.Clone(pikachu)
+	corrupt[len(corrupt)/2] ^= 0xff
+
+	tests := map[string][]byte{
+		"empty":           {},
+		"truncated":       pikachu[:len(pikachu)/2],
+		"corrupt":         corrupt,
+		"trailing data":   append(bytes.Clone(pikachu), 0),
+		"large window":    {0x11, 0x03},
+		"padding":         []byte("\x10\x00\x30hi\x03"),
+		"metadata length": {0xcc, 0x00, 0x00},
+	}
+
+	for name, data := range tests {
+		if _, err := decompress(pokesdk.BrotliDecompressor, data); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+
+	if _, err := decompress(pokesdk.BrotliDecompressor, pikachu[:len(pikachu)/2]); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("expected unexpected EOF for truncated data, got %v", err)
+	}
+}
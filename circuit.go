@@ -0,0 +1,201 @@
+package pokesdk
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without making a request when the circuit
+// breaker for every endpoint a request could be sent to is open.
+var ErrCircuitOpen = errors.New("circuit open")
+
+// CircuitState is the state of a circuit breaker.
+type CircuitState int
+
+const (
+	// CircuitClosed lets requests through while counting failures.
+	CircuitClosed CircuitState = iota
+
+	// CircuitOpen rejects requests until the cool-down has passed.
+	CircuitOpen
+
+	// CircuitHalfOpen lets a limited number of trial requests through. A
+	// success closes the circuit and a failure opens it again.
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// CircuitBreaker configures circuit breaking for requests to the API. Each
+// endpoint (see `Config.Endpoints`) gets its own circuit. Network errors, 5xx
+// and 429 responses count as failures.
+//
+//	sdk := pokesdk.New(pokesdk.Config{
+//		CircuitBreaker: &pokesdk.CircuitBreaker{
+//			FailureThreshold: 5,
+//			Cooldown:         time.Minute,
+//			OnStateChange: func(endpoint string, from, to pokesdk.CircuitState) {
+//				log.Printf("circuit for %s is now %s", endpoint, to)
+//			},
+//		},
+//	})
+type CircuitBreaker struct {
+	// FailureThreshold is the number of consecutive failures which opens the
+	// circuit. Defaults to `DefaultEndpointFailureThreshold`.
+	FailureThreshold int
+
+	// Cooldown is how long the circuit stays open before trial requests are
+	// let through. Defaults to `DefaultEndpointCooldown`.
+	Cooldown time.Duration
+
+	// HalfOpenRequests is the number of concurrent trial requests allowed
+	// while half-open. Defaults to one.
+	HalfOpenRequests int
+
+	// OnStateChange is called whenever an endpoint's circuit changes state.
+	OnStateChange func(endpoint string, from, to CircuitState)
+}
+
+// breaker is the circuit for a single endpoint.
+type breaker struct {
+	name   string
+	config CircuitBreaker
+
+	mu       sync.Mutex
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	trials   int
+
+	// changes are reported once the lock is released, so that callbacks may
+	// safely inspect the SDK.
+	changes [][2]CircuitState
+}
+
+func newBreaker(name string, config CircuitBreaker) *breaker {
+	if config.FailureThreshold < 1 {
+		config.FailureThreshold = DefaultEndpointFailureThreshold
+	}
+	if config.Cooldown <= 0 {
+		config.Cooldown = DefaultEndpointCooldown
+	}
+	if config.HalfOpenRequests < 1 {
+		config.HalfOpenRequests = 1
+	}
+	return &breaker{name: name, config: config}
+}
+
+// current returns the state, treating an open circuit whose cool-down has
+// passed as half-open.
+func (b *breaker) current() CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == CircuitOpen && time.Since(b.openedAt) >= b.config.Cooldown {
+		return CircuitHalfOpen
+	}
+	return b.state
+}
+
+// available returns whether a request would currently be allowed, without
+// reserving a trial.
+func (b *breaker) available() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case CircuitOpen:
+		return time.Since(b.openedAt) >= b.config.Cooldown
+	case CircuitHalfOpen:
+		return b.trials < b.config.HalfOpenRequests
+	}
+	return true
+}
+
+// allow returns whether a request may be made, reserving a trial when
+// half-open. Every allowed request must be followed by `success`, `failure`
+// or `release`.
+func (b *breaker) allow() bool {
+	b.mu.Lock()
+	if b.state == CircuitOpen && time.Since(b.openedAt) >= b.config.Cooldown {
+		b.transition(CircuitHalfOpen)
+	}
+
+	allowed := true
+	switch b.state {
+	case CircuitOpen:
+		allowed = false
+	case CircuitHalfOpen:
+		allowed = b.trials < b.config.HalfOpenRequests
+		if allowed {
+			b.trials++
+		}
+	}
+	b.unlock()
+	return allowed
+}
+
+func (b *breaker) success() {
+	b.mu.Lock()
+	b.failures = 0
+	if b.state != CircuitClosed {
+		b.transition(CircuitClosed)
+	}
+	b.unlock()
+}
+
+func (b *breaker) failure() {
+	b.mu.Lock()
+	b.failures++
+	switch b.state {
+	case CircuitClosed:
+		if b.failures >= b.config.FailureThreshold {
+			b.transition(CircuitOpen)
+		}
+	case CircuitHalfOpen:
+		b.transition(CircuitOpen)
+	}
+	b.unlock()
+}
+
+// release gives up a reserved trial without recording a result, e.g. when
+// the caller canceled the request.
+func (b *breaker) release() {
+	b.mu.Lock()
+	if b.state == CircuitHalfOpen && b.trials > 0 {
+		b.trials--
+	}
+	b.mu.Unlock()
+}
+
+// transition changes the state. Must be called with the lock held.
+func (b *breaker) transition(to CircuitState) {
+	from := b.state
+	b.state = to
+	b.trials = 0
+	if to == CircuitOpen {
+		b.openedAt = time.Now()
+	}
+	if b.config.OnStateChange != nil {
+		b.changes = append(b.changes, [2]CircuitState{from, to})
+	}
+}
+
+// unlock releases the lock and then reports any state changes.
+func (b *breaker) unlock() {
+	changes := b.changes
+	b.changes = nil
+	b.mu.Unlock()
+	for _, c := range changes {
+		b.config.OnStateChange(b.name, c[0], c[1])
+	}
+}
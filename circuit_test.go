@@ -0,0 +1,159 @@
+package pokesdk_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/pokesdk"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	ctx := context.Background()
+
+	transport := &mockTransport{}
+	transport.Expect("https://pokeapi.co/api/v2/pokemon/pikachu", http.StatusInternalServerError, ``)
+	transport.Expect("https://pokeapi.co/api/v2/pokemon/pikachu", http.StatusInternalServerError, ``)
+	transport.Expect("https://pokeapi.co/api/v2/pokemon/pikachu", http.StatusOK, `{"name":"pikachu"}`)
+
+	mu := sync.Mutex{}
+	changes := []string{}
+
+	sdk := pokesdk.New(pokesdk.Config{
+		Client: &http.Client{Transport: transport},
+		CircuitBreaker: &pokesdk.CircuitBreaker{
+			FailureThreshold: 2,
+			Cooldown:         50 * time.Millisecond,
+			OnStateChange: func(endpoint string, from, to pokesdk.CircuitState) {
+				mu.Lock()
+				defer mu.Unlock()
+				changes = append(changes, endpoint+" "+from.String()+" -> "+to.String())
+			},
+		},
+	})
+
+	for i := 0; i < 2; i++ {
+		if _, err := sdk.GetPokemon(ctx, "pikachu"); !errors.Is(err, pokesdk.APIError) {
+			t.Fatalf("expected API error, got %v", err)
+		}
+	}
+
+	if state := sdk.CircuitStates()["https://pokeapi.co"]; state != pokesdk.CircuitOpen {
+		t.Errorf("expected open circuit, got %s", state)
+	}
+
+	// Fails fast without making a request.
+	if _, err := sdk.GetPokemon(ctx, "pikachu"); !errors.Is(err, pokesdk.ErrCircuitOpen) {
+		t.Fatalf("expected circuit open error, got %v", err)
+	}
+
+	if len(transport.requests) != 2 {
+		t.Errorf("expected 2 requests, got %d", len(transport.requests))
+	}
+
+	time.Sleep(60 * time.Millisecond)
+
+	if state := sdk.CircuitStates()["https://pokeapi.co"]; state != pokesdk.CircuitHalfOpen {
+		t.Errorf("expected half-open circuit, got %s", state)
+	}
+
+	if _, err := sdk.GetPokemon(ctx, "pikachu"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"https://pokeapi.co closed -> open",
+		"https://pokeapi.co open -> half-open",
+		"https://pokeapi.co half-open -> closed",
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(changes) != len(expected) {
+		t.Fatalf("expected changes %v, got %v", expected, changes)
+	}
+	for i := range expected {
+		if changes[i] != expected[i] {
+			t.Errorf("expected changes %v, got %v", expected, changes)
+		}
+	}
+}
+
+func TestCircuitBreakerHalfOpenFailure(t *testing.T) {
+	ctx := context.Background()
+
+	// Every request fails with an unexpected request error.
+	transport := &mockTransport{}
+
+	sdk := pokesdk.New(pokesdk.Config{
+		Client: &http.Client{Transport: transport},
+		CircuitBreaker: &pokesdk.CircuitBreaker{
+			FailureThreshold: 1,
+			Cooldown:         20 * time.Millisecond,
+		},
+	})
+
+	sdk.GetPokemon(ctx, "pikachu")
+	time.Sleep(30 * time.Millisecond)
+
+	// The trial request fails, so the circuit opens again.
+	if _, err := sdk.GetPokemon(ctx, "pikachu"); errors.Is(err, pokesdk.ErrCircuitOpen) {
+		t.Fatalf("expected a trial request, got %v", err)
+	}
+
+	if _, err := sdk.GetPokemon(ctx, "pikachu"); !errors.Is(err, pokesdk.ErrCircuitOpen) {
+		t.Fatalf("expected circuit open error, got %v", err)
+	}
+
+	if len(transport.requests) != 2 {
+		t.Errorf("expected 2 requests, got %d", len(transport.requests))
+	}
+}
+
+func TestCircuitBreakerEndpoints(t *testing.T) {
+	ctx := context.Background()
+
+	transport := &mockTransport{}
+	transport.Expect("https://us.example.com/api/v2/pokemon/pikachu", http.StatusOK, `{"name":"pikachu"}`)
+	transport.Expect("https://us.example.com/api/v2/pokemon/pikachu", http.StatusOK, `{"name":"pikachu"}`)
+
+	sdk := pokesdk.New(pokesdk.Config{
+		BaseURL:        "https://eu.example.com",
+		Client:         &http.Client{Transport: transport},
+		Endpoints:      []pokesdk.Endpoint{{URL: "https://us.example.com"}},
+		CircuitBreaker: &pokesdk.CircuitBreaker{FailureThreshold: 1},
+	})
+
+	for i := 0; i < 2; i++ {
+		if _, err := sdk.GetPokemon(ctx, "pikachu"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// The EU circuit is open after the first failure, so it is not retried.
+	if len(transport.requests) != 3 {
+		t.Errorf("expected 3 requests, got %d", len(transport.requests))
+	}
+}
+
+func TestCircuitBreakerPaginator(t *testing.T) {
+	ctx := context.Background()
+
+	sdk := pokesdk.New(pokesdk.Config{
+		Client:         &http.Client{Transport: &mockTransport{}},
+		CircuitBreaker: &pokesdk.CircuitBreaker{FailureThreshold: 1},
+	})
+
+	sdk.GetPokemon(ctx, "pikachu")
+
+	var err error
+	for result := range sdk.ListPokemon().All(ctx) {
+		err = result.Error
+	}
+
+	if !errors.Is(err, pokesdk.ErrCircuitOpen) {
+		t.Errorf("expected circuit open error, got %v", err)
+	}
+}
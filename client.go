@@ -0,0 +1,56 @@
+package pokesdk
+
+import (
+	"net"
+	"net/http"
+	"time"
+)
+
+// DefaultTimeout is the default overall timeout for a request, including
+// reading the response body, when the SDK builds its own client.
+var DefaultTimeout = 30 * time.Second
+
+// newClient builds a client tuned for the API from the config, with
+// timeouts so a stuck connection can never hang forever and a connection
+// pool large enough for parallel crawls.
+func newClient(config Config) *http.Client {
+	timeout := durationOr(config.Timeout, DefaultTimeout)
+	if timeout < 0 {
+		timeout = 0
+	}
+
+	dialer := &net.Dialer{
+		Timeout:   durationOr(config.DialTimeout, 5*time.Second),
+		KeepAlive: 30 * time.Second,
+	}
+
+	maxIdlePerHost := config.MaxIdleConnsPerHost
+	if maxIdlePerHost == 0 {
+		maxIdlePerHost = 16
+	}
+
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			Proxy:       http.ProxyFromEnvironment,
+			DialContext: dialer.DialContext,
+			// HTTP/2 must be enabled explicitly when using a custom dialer.
+			ForceAttemptHTTP2:     !config.DisableHTTP2,
+			TLSHandshakeTimeout:   durationOr(config.TLSHandshakeTimeout, 5*time.Second),
+			ResponseHeaderTimeout: durationOr(config.ResponseHeaderTimeout, 10*time.Second),
+			ExpectContinueTimeout: time.Second,
+			MaxIdleConns:          max(100, maxIdlePerHost),
+			MaxIdleConnsPerHost:   maxIdlePerHost,
+			MaxConnsPerHost:       config.MaxConnsPerHost,
+			IdleConnTimeout:       durationOr(config.IdleConnTimeout, 90*time.Second),
+		},
+	}
+}
+
+// durationOr returns the duration, or the fallback if it is zero.
+func durationOr(d, fallback time.Duration) time.Duration {
+	if d == 0 {
+		return fallback
+	}
+	return d
+}
@@ -0,0 +1,89 @@
+package pokesdk_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/pokesdk"
+)
+
+func TestDefaultClient(t *testing.T) {
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"name":"pikachu"}`))
+	}))
+	defer server.Close()
+
+	sdk := pokesdk.New(pokesdk.Config{BaseURL: server.URL})
+
+	pika, err := sdk.GetPokemon(ctx, "pikachu")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if pika.Name != "pikachu" {
+		t.Errorf("expected pikachu, got %s", pika.Name)
+	}
+}
+
+func TestDefaultClientTimeouts(t *testing.T) {
+	ctx := context.Background()
+
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	for name, config := range map[string]pokesdk.Config{
+		"overall":         {Timeout: 20 * time.Millisecond},
+		"response-header": {ResponseHeaderTimeout: 20 * time.Millisecond},
+	} {
+		t.Run(name, func(t *testing.T) {
+			config.BaseURL = server.URL
+			sdk := pokesdk.New(config)
+
+			start := time.Now()
+			_, err := sdk.GetPokemon(ctx, "pikachu")
+
+			var netErr net.Error
+			if !errors.As(err, &netErr) || !netErr.Timeout() {
+				t.Errorf("expected timeout, got %v", err)
+			}
+
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Errorf("expected to fail quickly, took %s", elapsed)
+			}
+		})
+	}
+}
+
+func TestDefaultClientRedirect(t *testing.T) {
+	ctx := context.Background()
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Same server, but a host which is not allowed.
+		target := strings.Replace(server.URL, "127.0.0.1", "localhost", 1)
+		http.Redirect(w, r, target+"/elsewhere", http.StatusFound)
+	}))
+	defer server.Close()
+
+	sdk := pokesdk.New(pokesdk.Config{BaseURL: server.URL})
+
+	if _, err := sdk.GetPokemon(ctx, "pikachu"); !errors.Is(err, pokesdk.DisallowedURLError) {
+		t.Errorf("expected disallowed URL error, got %v", err)
+	}
+}
@@ -0,0 +1,106 @@
+// Command pokeexport exports lists from the Pokemon API to CSV, JSONL or
+// Parquet.
+//
+//	pokeexport -list pokemon -hydrate -format csv -o pokemon.csv
+//	pokeexport -list types -format jsonl
+//	pokeexport -hydrate -format parquet -o pokemon.parquet
+//	pokeexport -hydrate -columns id,pokemon=name,type_1,hp
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/danielgtaylor/pokesdk"
+	"github.com/danielgtaylor/pokesdk/export"
+)
+
+func main() {
+	list := flag.String("list", "pokemon", "list to export: pokemon, types or generations")
+	hydrate := flag.Bool("hydrate", false, "fetch full Pokemon for each list item (pokemon list only)")
+	format := flag.String("format", "csv", "output format: "+strings.Join(export.FormatNames(), ", "))
+	columns := flag.String("columns", "", "comma-separated columns to export, optionally renamed as `new=old`")
+	output := flag.String("o", "", "output file (default stdout)")
+	baseURL := flag.String("base-url", "", "API base URL (default "+pokesdk.PokeAPIBaseURL+")")
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := run(ctx, *list, *hydrate, *format, *columns, *output, *baseURL); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(ctx context.Context, list string, hydrate bool, formatName, columns, output, baseURL string) error {
+	format, ok := export.Formats[formatName]
+	if !ok {
+		return fmt.Errorf("unknown format %q, expected one of: %s", formatName, strings.Join(export.FormatNames(), ", "))
+	}
+
+	var names []string
+	if columns != "" {
+		names = strings.Split(columns, ",")
+	}
+
+	var w io.Writer = os.Stdout
+	if output != "" {
+		f, err := os.Create(output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	sdk := pokesdk.New(pokesdk.Config{BaseURL: baseURL})
+
+	var p *pokesdk.Paginator[pokesdk.NamedLink]
+	switch list {
+	case "pokemon":
+		p = sdk.ListPokemon()
+	case "types":
+		p = sdk.ListTypes()
+	case "generations":
+		p = sdk.ListGenerations()
+	default:
+		return fmt.Errorf("unknown list %q", list)
+	}
+	p.ErrorPolicy = pokesdk.PageErrorPolicy{Retries: 3}
+	p.DriftPolicy = pokesdk.DriftDedupe
+
+	var n int
+	var err error
+	if hydrate {
+		if list != "pokemon" {
+			return fmt.Errorf("only the pokemon list can be hydrated")
+		}
+		cols := export.PokemonColumns
+		if names != nil {
+			if cols, err = export.Select(cols, names...); err != nil {
+				return err
+			}
+		}
+		n, err = export.Pokemon(ctx, sdk, p, format, w, cols)
+	} else {
+		cols := export.LinkColumns
+		if names != nil {
+			if cols, err = export.Select(cols, names...); err != nil {
+				return err
+			}
+		}
+		n, err = export.List(ctx, p, format, w, cols)
+	}
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Exported %d rows", n)
+	return nil
+}
@@ -0,0 +1,141 @@
+package pokesdk
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// flight is a single in-progress call shared by one or more callers.
+type flight struct {
+	done    chan struct{}
+	value   any
+	err     error
+	waiters int
+	cancel  context.CancelFunc
+}
+
+// flightGroup deduplicates concurrent calls with the same key so that they
+// share a single execution and result.
+type flightGroup struct {
+	mu      sync.Mutex
+	flights map[string]*flight
+}
+
+// do runs `fn` once for all concurrent callers with the same key. The call
+// runs with a context that keeps the values of the first caller's context but
+// is only canceled once every waiting caller has given up, so each caller can
+// still cancel its own wait. The returned boolean is true if the result was
+// shared with an earlier caller.
+func (g *flightGroup) do(ctx context.Context, key string, fn func(ctx context.Context) (any, error)) (any, bool, error) {
+	g.mu.Lock()
+	if g.flights == nil {
+		g.flights = map[string]*flight{}
+	}
+
+	f, shared := g.flights[key]
+	if !shared {
+		fctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		f = &flight{done: make(chan struct{}), cancel: cancel}
+		g.flights[key] = f
+
+		go func() {
+			f.value, f.err = fn(fctx)
+			cancel()
+
+			g.mu.Lock()
+			if g.flights[key] == f {
+				delete(g.flights, key)
+			}
+			g.mu.Unlock()
+			close(f.done)
+		}()
+	}
+	f.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-f.done:
+		return f.value, shared, f.err
+	case <-ctx.Done():
+		g.mu.Lock()
+		f.waiters--
+		if f.waiters == 0 {
+			// Nobody is waiting anymore, so stop the call and make sure new
+			// callers start a fresh one.
+			f.cancel()
+			if g.flights[key] == f {
+				delete(g.flights, key)
+			}
+		}
+		g.mu.Unlock()
+		return nil, shared, ctx.Err()
+	}
+}
+
+// bufferedResponse is a fully read response which can be handed out to
+// multiple callers.
+type bufferedResponse struct {
+	resp *http.Response
+	body []byte
+}
+
+// requestShared makes a GET request shared between concurrent callers of the
+// same URL. Each caller gets its own copy of the response and body.
+func (s *SDK) requestShared(ctx context.Context, url string) (*http.Response, error) {
+	v, _, err := s.flights.do(ctx, http.MethodGet+" "+url, func(ctx context.Context) (any, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+
+		resp, err := s.do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response: %w", err)
+		}
+		return &bufferedResponse{resp: resp, body: body}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	buffered := v.(*bufferedResponse)
+	resp := *buffered.resp
+	resp.Header = buffered.resp.Header.Clone()
+	resp.Body = io.NopCloser(bytes.NewReader(buffered.body))
+	return &resp, nil
+}
+
+// followResult is the shared result of following a URL.
+type followResult[T any] struct {
+	value *T
+	meta  *Response
+}
+
+// followShared follows a URL, sharing the request and decoded result between
+// concurrent callers of the same URL and type.
+func followShared[T any](ctx context.Context, sdk *SDK, url string) (*T, *Response, error) {
+	key := fmt.Sprintf("%s %T %s", http.MethodGet, (*T)(nil), url)
+	v, shared, err := sdk.flights.do(ctx, key, func(ctx context.Context) (any, error) {
+		value, meta, err := follow[T](ctx, sdk, url)
+		return followResult[T]{value: value, meta: meta}, err
+	})
+
+	result, _ := v.(followResult[T])
+	if result.meta != nil {
+		// Copy the metadata so each caller knows whether it was shared.
+		meta := *result.meta
+		meta.Shared = shared
+		result.meta = &meta
+	}
+	return result.value, result.meta, err
+}
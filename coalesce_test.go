@@ -0,0 +1,195 @@
+package pokesdk_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/pokesdk"
+)
+
+// blockingTransport holds every request until released, counting how many
+// requests were made.
+type blockingTransport struct {
+	calls   int64
+	release chan struct{}
+}
+
+func (t *blockingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	atomic.AddInt64(&t.calls, 1)
+	select {
+	case <-t.release:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(`{"name":"pikachu"}`)),
+	}, nil
+}
+
+func TestCoalesceFollow(t *testing.T) {
+	ctx := context.Background()
+
+	transport := &blockingTransport{release: make(chan struct{})}
+	sdk := pokesdk.New(pokesdk.Config{
+		Client:   &http.Client{Transport: transport},
+		Coalesce: true,
+	})
+
+	results := make([]*pokesdk.Pokemon, 50)
+	started := sync.WaitGroup{}
+	finished := sync.WaitGroup{}
+	for i := range results {
+		started.Add(1)
+		finished.Add(1)
+		go func(i int) {
+			defer finished.Done()
+			started.Done()
+			pika, err := sdk.GetPokemon(ctx, "pikachu")
+			if err != nil {
+				t.Errorf("failed to get pikachu: %v", err)
+			}
+			results[i] = pika
+		}(i)
+	}
+
+	started.Wait()
+	time.Sleep(50 * time.Millisecond)
+	close(transport.release)
+	finished.Wait()
+
+	if calls := atomic.LoadInt64(&transport.calls); calls != 1 {
+		t.Errorf("expected 1 request, got %d", calls)
+	}
+
+	for _, r := range results {
+		if r != results[0] {
+			t.Fatalf("expected all callers to share one decoded result")
+		}
+	}
+}
+
+func TestCoalesceCancel(t *testing.T) {
+	transport := &blockingTransport{release: make(chan struct{})}
+	sdk := pokesdk.New(pokesdk.Config{
+		Client:   &http.Client{Transport: transport},
+		Coalesce: true,
+	})
+
+	cancelCtx, cancel := context.WithCancel(context.Background())
+	canceled := make(chan error)
+	go func() {
+		_, err := sdk.GetPokemon(cancelCtx, "pikachu")
+		canceled <- err
+	}()
+
+	shared := make(chan *pokesdk.Response)
+	go func() {
+		for atomic.LoadInt64(&transport.calls) == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		_, resp, err := sdk.GetPokemonWithResponse(context.Background(), "pikachu")
+		if err != nil {
+			t.Errorf("failed to get pikachu: %v", err)
+		}
+		shared <- resp
+	}()
+
+	// The canceled caller returns right away without affecting the other.
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	if err := <-canceled; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected canceled error, got %v", err)
+	}
+
+	close(transport.release)
+	if resp := <-shared; resp == nil || !resp.Shared {
+		t.Errorf("expected shared response, got %+v", resp)
+	}
+
+	if calls := atomic.LoadInt64(&transport.calls); calls != 1 {
+		t.Errorf("expected 1 request, got %d", calls)
+	}
+}
+
+func TestCoalesceRequest(t *testing.T) {
+	ctx := context.Background()
+
+	transport := &blockingTransport{release: make(chan struct{})}
+	sdk := pokesdk.New(pokesdk.Config{
+		Client:   &http.Client{Transport: transport},
+		Coalesce: true,
+	})
+
+	bodies := make(chan string, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			resp, err := sdk.Request(ctx, http.MethodGet, "https://pokeapi.co/api/v2/pokemon/pikachu", nil)
+			if err != nil {
+				t.Errorf("request failed: %v", err)
+				bodies <- ""
+				return
+			}
+			defer resp.Body.Close()
+			b, _ := io.ReadAll(resp.Body)
+			bodies <- string(b)
+		}()
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(transport.release)
+
+	for i := 0; i < 2; i++ {
+		if b := <-bodies; b != `{"name":"pikachu"}` {
+			t.Errorf("unexpected body: %s", b)
+		}
+	}
+
+	if calls := atomic.LoadInt64(&transport.calls); calls != 1 {
+		t.Errorf("expected 1 request, got %d", calls)
+	}
+}
+
+func TestCoalesceTimeout(t *testing.T) {
+	ctx := context.Background()
+
+	transport := &blockingTransport{release: make(chan struct{})}
+	sdk := pokesdk.New(pokesdk.Config{
+		Client:   &http.Client{Transport: transport},
+		Coalesce: true,
+	})
+
+	// A shared call started without a timeout must not stop the caller's
+	// own timeout from applying to its request.
+	go sdk.GetPokemon(ctx, "pikachu")
+	time.Sleep(20 * time.Millisecond)
+
+	errs := make(chan error, 2)
+	go func() {
+		_, err := sdk.GetPokemon(ctx, "pikachu", pokesdk.WithTimeout(20*time.Millisecond))
+		errs <- err
+	}()
+	go func() {
+		_, err := sdk.GetPokemon(ctx, "pikachu", pokesdk.WithRetries(0))
+		errs <- err
+	}()
+
+	if err := <-errs; !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+	close(transport.release)
+	if err := <-errs; err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if calls := atomic.LoadInt64(&transport.calls); calls != 3 {
+		t.Errorf("expected 3 requests, got %d", calls)
+	}
+}
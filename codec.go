@@ -0,0 +1,111 @@
+package pokesdk
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Codec decodes response bodies of a specific media type into Go values.
+// Custom codecs, e.g. for MessagePack or CBOR, can be registered via
+// `Config.Codecs` when talking to servers which support those formats.
+//
+//	sdk := pokesdk.New(pokesdk.Config{
+//		Codecs: map[string]pokesdk.Codec{
+//			"application/msgpack": pokesdk.CodecFunc(msgpack.Unmarshal),
+//		},
+//	})
+type Codec interface {
+	Unmarshal(data []byte, v any) error
+}
+
+// CodecFunc adapts an unmarshal function into a `Codec`.
+type CodecFunc func(data []byte, v any) error
+
+// Unmarshal calls the wrapped function.
+func (f CodecFunc) Unmarshal(data []byte, v any) error {
+	return f(data, v)
+}
+
+// JSONMediaType is the media type of the default JSON codec.
+const JSONMediaType = "application/json"
+
+// accept builds an `Accept` header value from the registered codecs. Custom
+// codecs are preferred over JSON, since they are only registered when the
+// server is known to support them.
+func accept(codecs map[string]Codec) string {
+	types := []string{}
+	for mt := range codecs {
+		if mt != JSONMediaType {
+			types = append(types, mt)
+		}
+	}
+	sort.Strings(types)
+
+	if len(types) == 0 {
+		return JSONMediaType
+	}
+	return strings.Join(append(types, JSONMediaType+";q=0.9"), ", ")
+}
+
+// lenientTypes are generic media types which some mirrors and caches send
+// for JSON responses. Unless a codec is registered for them, they are decoded
+// as JSON.
+var lenientTypes = map[string]bool{
+	"text/plain":               true,
+	"application/octet-stream": true,
+}
+
+// codecFor returns the codec to use for a response based on its content type.
+// Responses without a content type are assumed to be JSON.
+func (s *SDK) codecFor(resp *http.Response) (Codec, error) {
+	ct := resp.Header.Get("Content-Type")
+	if ct == "" {
+		return s.codecs[JSONMediaType], nil
+	}
+
+	mt, _, err := mime.ParseMediaType(ct)
+	if err != nil {
+		return nil, fmt.Errorf("invalid content type %q: %w", ct, err)
+	}
+
+	if codec, ok := s.codecs[mt]; ok {
+		return codec, nil
+	}
+
+	if strings.HasSuffix(mt, "+json") || lenientTypes[mt] {
+		return s.codecs[JSONMediaType], nil
+	}
+
+	return nil, fmt.Errorf("unsupported content type %q", ct)
+}
+
+// decode unmarshals a response body into `v` using the codec matching the
+// response's content type, then validates it against the model.
+func (s *SDK) decode(resp *http.Response, url string, data []byte, v any) error {
+	codec, err := s.codecFor(resp)
+	if err != nil {
+		return err
+	}
+
+	if err := codec.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if s.keepUnknown {
+		if err := fillExtra(codec, data, v); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+
+	if err := s.validate(codec, url, data, v); err != nil {
+		return err
+	}
+
+	if s.rewriteResponses {
+		s.RewriteURLs(v)
+	}
+	return nil
+}
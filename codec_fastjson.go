@@ -0,0 +1,8 @@
+//go:build pokesdk_fastjson
+
+package pokesdk
+
+// JSONCodec is the default codec for JSON responses, using the SDK's own
+// decoder since this build has the `pokesdk_fastjson` tag. It is also used by
+// the models' own decoding methods.
+var JSONCodec Codec = CodecFunc(unmarshalFast)
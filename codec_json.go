@@ -0,0 +1,10 @@
+//go:build !pokesdk_fastjson
+
+package pokesdk
+
+import "encoding/json"
+
+// JSONCodec is the default codec for JSON responses. It is also used by the
+// models' own decoding methods. Build with `-tags pokesdk_fastjson` to use
+// the SDK's own faster decoder instead.
+var JSONCodec Codec = CodecFunc(json.Unmarshal)
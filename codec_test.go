@@ -0,0 +1,177 @@
+package pokesdk_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/danielgtaylor/pokesdk"
+)
+
+// testCodec decodes a trivial `name=value` format into a Pokemon.
+var testCodec = pokesdk.CodecFunc(func(data []byte, v any) error {
+	name, ok := strings.CutPrefix(string(data), "name=")
+	if !ok {
+		return errors.New("invalid test format")
+	}
+	if p, ok := v.(**pokesdk.Pokemon); ok {
+		*p = &pokesdk.Pokemon{Name: name}
+	}
+	return nil
+})
+
+func expectContentType(transport *mockTransport, url, contentType, body string) {
+	transport.ExpectResponse(url, &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{contentType}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	})
+}
+
+func TestCodecNegotiation(t *testing.T) {
+	ctx := context.Background()
+
+	transport := &mockTransport{}
+	expectContentType(transport, "https://pokeapi.co/api/v2/pokemon/pikachu", "application/x-test", "name=pikachu")
+	expectContentType(transport, "https://pokeapi.co/api/v2/pokemon/pikachu", "application/json; charset=utf-8", `{"name":"raichu"}`)
+	expectContentType(transport, "https://pokeapi.co/api/v2/pokemon/pikachu", "application/problem+json", `{"name":"pichu"}`)
+
+	sdk := pokesdk.New(pokesdk.Config{
+		Client: &http.Client{Transport: transport},
+		Codecs: map[string]pokesdk.Codec{"application/x-test": testCodec},
+	})
+
+	for _, expected := range []string{"pikachu", "raichu", "pichu"} {
+		pika, err := sdk.GetPokemon(ctx, "pikachu")
+		if err != nil {
+			t.Fatalf("failed to get pokemon: %v", err)
+		}
+		if pika.Name != expected {
+			t.Errorf("expected %s, got %s", expected, pika.Name)
+		}
+	}
+
+	if accept := transport.requests[0].Header.Get("Accept"); accept != "application/x-test, application/json;q=0.9" {
+		t.Errorf("unexpected accept header: %s", accept)
+	}
+}
+
+func TestCodecDefaultAccept(t *testing.T) {
+	ctx := context.Background()
+
+	transport := &mockTransport{}
+	transport.Expect("https://pokeapi.co/api/v2/pokemon/pikachu", http.StatusOK, `{"name":"pikachu"}`)
+
+	sdk := pokesdk.New(pokesdk.Config{
+		Client: &http.Client{Transport: transport},
+	})
+
+	if _, err := sdk.GetPokemon(ctx, "pikachu"); err != nil {
+		t.Fatalf("failed to get pokemon: %v", err)
+	}
+
+	if accept := transport.requests[0].Header.Get("Accept"); accept != "application/json" {
+		t.Errorf("unexpected accept header: %s", accept)
+	}
+}
+
+func TestCodecUnsupported(t *testing.T) {
+	ctx := context.Background()
+
+	transport := &mockTransport{}
+	expectContentType(transport, "https://pokeapi.co/api/v2/pokemon/pikachu", "text/html", "<html></html>")
+
+	sdk := pokesdk.New(pokesdk.Config{
+		Client: &http.Client{Transport: transport},
+	})
+
+	if _, err := sdk.GetPokemon(ctx, "pikachu"); err == nil || !strings.Contains(err.Error(), "unsupported content type") {
+		t.Errorf("expected unsupported content type error, got %v", err)
+	}
+}
+
+func TestCodecLenientTypes(t *testing.T) {
+	ctx := context.Background()
+
+	transport := &mockTransport{}
+	expectContentType(transport, "https://pokeapi.co/api/v2/pokemon/pikachu", "text/plain; charset=utf-8", `{"name":"pikachu"}`)
+	expectContentType(transport, "https://pokeapi.co/api/v2/pokemon/pikachu", "application/octet-stream", `{"name":"raichu"}`)
+
+	sdk := pokesdk.New(pokesdk.Config{
+		Client: &http.Client{Transport: transport},
+	})
+
+	for _, expected := range []string{"pikachu", "raichu"} {
+		pika, err := sdk.GetPokemon(ctx, "pikachu")
+		if err != nil {
+			t.Fatalf("failed to get pokemon: %v", err)
+		}
+		if pika.Name != expected {
+			t.Errorf("expected %s, got %s", expected, pika.Name)
+		}
+	}
+}
+
+// benchmarkPokemon builds a payload about the size of a real Pokemon
+// response, with many moves and sprites for every generation.
+func benchmarkPokemon() []byte {
+	link := func(name string) map[string]any {
+		return map[string]any{"name": name, "url": "https://pokeapi.co/api/v2/" + name + "/"}
+	}
+	set := map[string]any{}
+	for _, key := range []string{"front_default", "front_shiny", "back_default", "back_shiny", "front_female", "back_female"} {
+		set[key] = "https://example.com/sprites/" + key + ".png"
+	}
+
+	moves := []any{}
+	for i := 0; i < 150; i++ {
+		details := []any{}
+		for j := 0; j < 6; j++ {
+			details = append(details, map[string]any{
+				"level_learned_at":  j,
+				"version_group":     link(fmt.Sprintf("version-group-%d", j)),
+				"move_learn_method": link("level-up"),
+			})
+		}
+		moves = append(moves, map[string]any{"move": link(fmt.Sprintf("move-%d", i)), "version_group_details": details})
+	}
+
+	versions := map[string]any{}
+	for _, gen := range []string{"generation-i", "generation-ii", "generation-iii", "generation-iv", "generation-vi", "generation-vii", "generation-viii", "generation-ix"} {
+		versions[gen] = map[string]any{"game-a": set, "game-b": set}
+	}
+	versions["generation-v"] = map[string]any{"black-white": map[string]any{"animated": set, "front_default": "https://example.com/v.png"}}
+
+	b, _ := json.Marshal(map[string]any{
+		"id":      25,
+		"name":    "pikachu",
+		"moves":   moves,
+		"species": link("pikachu"),
+		"sprites": map[string]any{
+			"front_default": "https://example.com/25.png",
+			"other":         map[string]any{"home": set, "official-artwork": set, "showdown": set},
+			"versions":      versions,
+		},
+	})
+	return b
+}
+
+// BenchmarkJSONCodec measures decoding a Pokemon with the default codec.
+// Compare against a build with `-tags pokesdk_fastjson` to see the difference.
+func BenchmarkJSONCodec(b *testing.B) {
+	data := benchmarkPokemon()
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		var p *pokesdk.Pokemon
+		if err := pokesdk.JSONCodec.Unmarshal(data, &p); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package pokesdk
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Decompressor wraps a compressed response body in a reader which returns the
+// decompressed data. Decompressors for `gzip`, `br` and `zstd` are built in,
+// and others can be registered via `Config.Decompressors`.
+//
+//	sdk := pokesdk.New(pokesdk.Config{
+//		Decompressors: map[string]pokesdk.Decompressor{
+//			"deflate": func(r io.Reader) (io.ReadCloser, error) {
+//				return flate.NewReader(r), nil
+//			},
+//		},
+//	})
+type Decompressor func(r io.Reader) (io.ReadCloser, error)
+
+// GzipDecompressor decompresses `gzip` encoded responses. It is registered by
+// default.
+func GzipDecompressor(r io.Reader) (io.ReadCloser, error) {
+	return gzip.NewReader(r)
+}
+
+// ResponseTooLargeError is returned when reading a response body which is
+// larger than `Config.MaxResponseBytes` after decompression.
+type ResponseTooLargeError struct {
+	Limit int64
+}
+
+func (e *ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("response body exceeds limit of %d bytes", e.Limit)
+}
+
+// acceptEncoding builds an `Accept-Encoding` header value from the registered
+// decompressors. Other encodings are preferred over gzip, since brotli and zstd
+// compress better and custom ones are only registered when they do too. Only
+// `identity` is accepted if every encoding has been disabled.
+func acceptEncoding(decompressors map[string]Decompressor) string {
+	encodings := []string{}
+	for enc := range decompressors {
+		if enc != "gzip" {
+			encodings = append(encodings, enc)
+		}
+	}
+	sort.Strings(encodings)
+	if _, ok := decompressors["gzip"]; ok {
+		encodings = append(encodings, "gzip")
+	}
+	if len(encodings) == 0 {
+		return "identity"
+	}
+	return strings.Join(encodings, ", ")
+}
+
+// countingReader counts the bytes read through it.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// limitedReader fails with a `ResponseTooLargeError` once more than `limit`
+// bytes have been read.
+type limitedReader struct {
+	r     io.Reader
+	limit int64
+	n     int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.n += int64(n)
+	if l.n > l.limit {
+		return n, &ResponseTooLargeError{Limit: l.limit}
+	}
+	return n, err
+}
+
+// responseBody is a response body which has been transparently decompressed
+// and size-limited, and which tracks how many bytes went over the wire.
+type responseBody struct {
+	io.Reader
+	wire     *countingReader
+	encoding string
+	closers  []io.Closer
+}
+
+func (b *responseBody) Close() error {
+	var err error
+	for _, c := range b.closers {
+		if cerr := c.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+// wrapBody replaces the response body with one which is transparently
+// decompressed and limited to the configured maximum size.
+func (s *SDK) wrapBody(resp *http.Response) error {
+	if s.maxResponseBytes > 0 && resp.ContentLength > s.maxResponseBytes && resp.Header.Get("Content-Encoding") == "" {
+		return &ResponseTooLargeError{Limit: s.maxResponseBytes}
+	}
+
+	wire := &countingReader{r: resp.Body}
+	body := &responseBody{Reader: wire, wire: wire, closers: []io.Closer{resp.Body}}
+
+	if enc := strings.ToLower(resp.Header.Get("Content-Encoding")); enc != "" && enc != "identity" {
+		decompress, ok := s.decompressors[enc]
+		if !ok {
+			return fmt.Errorf("unsupported content encoding %q", enc)
+		}
+
+		r, err := decompress(wire)
+		if err != nil {
+			return fmt.Errorf("failed to decompress response: %w", err)
+		}
+
+		body.Reader = r
+		body.encoding = enc
+		body.closers = append([]io.Closer{r}, body.closers...)
+
+		resp.Header.Del("Content-Encoding")
+		resp.Header.Del("Content-Length")
+		resp.ContentLength = -1
+		resp.Uncompressed = true
+	}
+
+	if s.maxResponseBytes > 0 {
+		body.Reader = &limitedReader{r: body.Reader, limit: s.maxResponseBytes}
+	}
+
+	resp.Body = body
+	return nil
+}
@@ -0,0 +1,238 @@
+package pokesdk_test
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/danielgtaylor/pokesdk"
+)
+
+// bigPokemon is a highly compressible Pokemon payload.
+var bigPokemon = `{"name":"pikachu","moves":[` + strings.Repeat(`{"move":{"name":"thunder-shock","url":""},"version_group_details":[]},`, 200) + `{"move":{"name":"thunderbolt","url":""},"version_group_details":[]}]}`
+
+func compressed(t *testing.T, encoding, body string) io.ReadCloser {
+	buf := &bytes.Buffer{}
+	var w io.WriteCloser
+	switch encoding {
+	case "gzip":
+		w = gzip.NewWriter(buf)
+	case "deflate":
+		w, _ = flate.NewWriter(buf, flate.BestCompression)
+	}
+	if _, err := w.Write([]byte(body)); err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+	return io.NopCloser(buf)
+}
+
+// decompress reads all of `data` through a decompressor.
+func decompress(d pokesdk.Decompressor, data []byte) ([]byte, error) {
+	r, err := d(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+// pokemonList is the uncompressed content of `testdata/pokemon-list.ndjson.*`,
+// which is large enough for the decompressors to need to slide their window.
+func pokemonList() []byte {
+	buf := &bytes.Buffer{}
+	for i := 0; i < 20000; i++ {
+		fmt.Fprintf(buf, `{"id":%d,"name":"pokemon-%d"}`+"\n", i%1000+1, i%1000+1)
+	}
+	return buf.Bytes()
+}
+
+func TestCompressionGzip(t *testing.T) {
+	ctx := context.Background()
+
+	transport := &mockTransport{}
+	transport.ExpectResponse("https://pokeapi.co/api/v2/pokemon/pikachu", &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Encoding": []string{"gzip"}},
+		Body:       compressed(t, "gzip", bigPokemon),
+	})
+
+	sdk := pokesdk.New(pokesdk.Config{
+		Client: &http.Client{Transport: transport},
+	})
+
+	pika, resp, err := sdk.GetPokemonWithResponse(ctx, "pikachu")
+	if err != nil {
+		t.Fatalf("failed to get pikachu: %v", err)
+	}
+
+	if len(pika.Moves) != 201 {
+		t.Errorf("expected 201 moves, got %d", len(pika.Moves))
+	}
+
+	if resp.Encoding != "gzip" || resp.WireBytes >= int64(len(resp.Body)) || len(resp.Body) != len(bigPokemon) {
+		t.Errorf("unexpected byte accounting: encoding=%s wire=%d body=%d", resp.Encoding, resp.WireBytes, len(resp.Body))
+	}
+
+	if enc := transport.requests[0].Header.Get("Accept-Encoding"); enc != "br, zstd, gzip" {
+		t.Errorf("unexpected accept encoding: %s", enc)
+	}
+}
+
+func TestCompressionCustom(t *testing.T) {
+	ctx := context.Background()
+
+	transport := &mockTransport{}
+	transport.ExpectResponse("https://pokeapi.co/api/v2/pokemon/pikachu", &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Encoding": []string{"deflate"}},
+		Body:       compressed(t, "deflate", bigPokemon),
+	})
+	transport.ExpectResponse("https://pokeapi.co/api/v2/pokemon/pikachu", &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Encoding": []string{"compress"}},
+		Body:       io.NopCloser(strings.NewReader("...")),
+	})
+
+	sdk := pokesdk.New(pokesdk.Config{
+		Client: &http.Client{Transport: transport},
+		Decompressors: map[string]pokesdk.Decompressor{
+			"deflate": func(r io.Reader) (io.ReadCloser, error) {
+				return flate.NewReader(r), nil
+			},
+		},
+	})
+
+	pika, err := sdk.GetPokemon(ctx, "pikachu")
+	if err != nil {
+		t.Fatalf("failed to get pikachu: %v", err)
+	}
+	if pika.Name != "pikachu" {
+		t.Errorf("expected pikachu, got %s", pika.Name)
+	}
+
+	if enc := transport.requests[0].Header.Get("Accept-Encoding"); enc != "br, deflate, zstd, gzip" {
+		t.Errorf("unexpected accept encoding: %s", enc)
+	}
+
+	if _, err := sdk.GetPokemon(ctx, "pikachu"); err == nil || !strings.Contains(err.Error(), "unsupported content encoding") {
+		t.Errorf("expected unsupported encoding error, got %v", err)
+	}
+}
+
+func TestCompressionBuiltIn(t *testing.T) {
+	ctx := context.Background()
+
+	for encoding, file := range map[string]string{
+		"br":   "testdata/pokemon-pikachu.json.br",
+		"zstd": "testdata/pokemon-pikachu.json.zst",
+	} {
+		body, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		transport := &mockTransport{}
+		transport.ExpectResponse("https://pokeapi.co/api/v2/pokemon/pikachu", &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Encoding": []string{encoding}},
+			Body:       io.NopCloser(bytes.NewReader(body)),
+		})
+
+		sdk := pokesdk.New(pokesdk.Config{
+			Client: &http.Client{Transport: transport},
+		})
+
+		pika, resp, err := sdk.GetPokemonWithResponse(ctx, "pikachu")
+		if err != nil {
+			t.Fatalf("%s: failed to get pikachu: %v", encoding, err)
+		}
+		if pika.Name != "pikachu" {
+			t.Errorf("%s: expected pikachu, got %s", encoding, pika.Name)
+		}
+
+		if resp.Encoding != encoding || resp.WireBytes != int64(len(body)) || resp.WireBytes >= int64(len(resp.Body)) {
+			t.Errorf("%s: unexpected byte accounting: encoding=%s wire=%d body=%d", encoding, resp.Encoding, resp.WireBytes, len(resp.Body))
+		}
+	}
+}
+
+func TestCompressionDisabled(t *testing.T) {
+	ctx := context.Background()
+
+	transport := &mockTransport{}
+	transport.ExpectResponse("https://pokeapi.co/api/v2/pokemon/pikachu", &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Encoding": []string{"zstd"}},
+		Body:       io.NopCloser(strings.NewReader(zstdRaw)),
+	})
+
+	sdk := pokesdk.New(pokesdk.Config{
+		Client: &http.Client{Transport: transport},
+		Decompressors: map[string]pokesdk.Decompressor{
+			"gzip": nil,
+			"zstd": nil,
+		},
+	})
+
+	if _, err := sdk.GetPokemon(ctx, "pikachu"); err == nil || !strings.Contains(err.Error(), "unsupported content encoding") {
+		t.Errorf("expected unsupported encoding error, got %v", err)
+	}
+
+	if enc := transport.requests[0].Header.Get("Accept-Encoding"); enc != "br" {
+		t.Errorf("unexpected accept encoding: %s", enc)
+	}
+}
+
+func TestMaxResponseBytes(t *testing.T) {
+	ctx := context.Background()
+
+	// A small zstd body which decompresses to hundreds of KB.
+	list, err := os.ReadFile("testdata/pokemon-list.ndjson.zst")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	transport := &mockTransport{}
+	transport.ExpectResponse("https://pokeapi.co/api/v2/pokemon/pikachu", &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Encoding": []string{"gzip"}},
+		Body:       compressed(t, "gzip", bigPokemon),
+	})
+	transport.ExpectResponse("https://pokeapi.co/api/v2/pokemon/pikachu", &http.Response{
+		StatusCode:    http.StatusOK,
+		ContentLength: int64(len(bigPokemon)),
+		Body:          io.NopCloser(strings.NewReader(bigPokemon)),
+	})
+	transport.ExpectResponse("https://pokeapi.co/api/v2/pokemon/pikachu", &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Encoding": []string{"zstd"}},
+		Body:       io.NopCloser(bytes.NewReader(list)),
+	})
+	transport.Expect("https://pokeapi.co/api/v2/pokemon/pichu", http.StatusOK, `{"name":"pichu"}`)
+
+	sdk := pokesdk.New(pokesdk.Config{
+		Client:           &http.Client{Transport: transport},
+		MaxResponseBytes: 1024,
+	})
+
+	for i := 0; i < 3; i++ {
+		_, err := sdk.GetPokemon(ctx, "pikachu")
+		var tooLarge *pokesdk.ResponseTooLargeError
+		if !errors.As(err, &tooLarge) || tooLarge.Limit != 1024 {
+			t.Errorf("expected response too large error, got %v", err)
+		}
+	}
+
+	if _, err := sdk.GetPokemon(ctx, "pichu"); err != nil {
+		t.Errorf("expected small response to succeed: %v", err)
+	}
+}
@@ -0,0 +1,254 @@
+package pokesdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AuthError is returned when credentials could not be obtained, for example
+// because a token request was rejected.
+var AuthError = errors.New("auth error")
+
+// DefaultRefreshBefore is how long before expiry a cached token is refreshed
+// by default.
+var DefaultRefreshBefore = time.Minute
+
+// Credentials add authentication to outgoing requests. The SDK only applies
+// them to requests for the `BaseURL` host, so they are never leaked to other
+// hosts when following absolute URLs.
+type Credentials interface {
+	// Apply adds credentials to the request, for example by setting headers.
+	Apply(req *http.Request) error
+}
+
+// BearerToken is a static token sent in the `Authorization` header.
+type BearerToken string
+
+// Apply sets the `Authorization` header.
+func (t BearerToken) Apply(req *http.Request) error {
+	req.Header.Set("Authorization", "Bearer "+string(t))
+	return nil
+}
+
+// APIKey is a static key sent in a request header.
+type APIKey struct {
+	// Header is the name of the header, defaulting to `X-API-Key`.
+	Header string
+	Key    string
+}
+
+// Apply sets the API key header.
+func (k APIKey) Apply(req *http.Request) error {
+	header := k.Header
+	if header == "" {
+		header = "X-API-Key"
+	}
+	req.Header.Set(header, k.Key)
+	return nil
+}
+
+// BasicAuth sends a username and password using HTTP basic auth.
+type BasicAuth struct {
+	Username string
+	Password string
+}
+
+// Apply sets the `Authorization` header.
+func (b BasicAuth) Apply(req *http.Request) error {
+	req.SetBasicAuth(b.Username, b.Password)
+	return nil
+}
+
+// ClientCredentials fetches bearer tokens using the OAuth2 client credentials
+// grant. Tokens are cached and refreshed shortly before they expire, and
+// concurrent refreshes share a single token request. Use a pointer so the
+// cache is shared:
+//
+//	sdk := pokesdk.New(pokesdk.Config{
+//		Credentials: &pokesdk.ClientCredentials{
+//			TokenURL:     "https://auth.example.com/oauth2/token",
+//			ClientID:     "id",
+//			ClientSecret: "secret",
+//		},
+//	})
+type ClientCredentials struct {
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+
+	// Client is used for token requests and defaults to a client using
+	// `DefaultTimeout`.
+	Client *http.Client
+
+	// RefreshBefore is how long before expiry to refresh the token, and
+	// defaults to `DefaultRefreshBefore`. It is capped at half the token's
+	// lifetime so short-lived tokens are still reused.
+	RefreshBefore time.Duration
+
+	mu        sync.Mutex
+	token     string
+	refreshAt time.Time
+	flights   flightGroup
+}
+
+// Apply sets the `Authorization` header, fetching a new token if needed.
+func (c *ClientCredentials) Apply(req *http.Request) error {
+	token, err := c.Token(req.Context())
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+// Token returns a valid access token, fetching a new one if the cached token
+// is missing or about to expire.
+func (c *ClientCredentials) Token(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	if c.token != "" && (c.refreshAt.IsZero() || time.Now().Before(c.refreshAt)) {
+		token := c.token
+		c.mu.Unlock()
+		return token, nil
+	}
+	c.mu.Unlock()
+
+	v, _, err := c.flights.do(ctx, "token", func(ctx context.Context) (any, error) {
+		return c.fetch(ctx)
+	})
+	if err != nil {
+		return "", err
+	}
+	return v.(string), nil
+}
+
+// fetch requests a new token and caches it.
+func (c *ClientCredentials) fetch(ctx context.Context) (string, error) {
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(c.Scopes) > 0 {
+		form.Set("scope", strings.Join(c.Scopes, " "))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", JSONMediaType)
+	req.SetBasicAuth(url.QueryEscape(c.ClientID), url.QueryEscape(c.ClientSecret))
+
+	client := c.Client
+	if client == nil {
+		client = &http.Client{Timeout: DefaultTimeout}
+	}
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to make token request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read token response: %w", err)
+	}
+
+	if resp.StatusCode >= 300 {
+		return "", fmt.Errorf("%w: token request failed with %d: %s", AuthError, resp.StatusCode, string(data))
+	}
+
+	var body struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err := json.Unmarshal(data, &body); err != nil {
+		return "", fmt.Errorf("%w: failed to decode token response: %w", AuthError, err)
+	}
+	if body.AccessToken == "" {
+		return "", fmt.Errorf("%w: token response has no access token", AuthError)
+	}
+
+	var refreshAt time.Time
+	if body.ExpiresIn > 0 {
+		// Measure from when the request was sent to stay on the safe side.
+		lifetime := time.Duration(body.ExpiresIn) * time.Second
+		before := c.RefreshBefore
+		if before == 0 {
+			before = DefaultRefreshBefore
+		}
+		refreshAt = start.Add(lifetime - min(before, lifetime/2))
+	}
+
+	c.mu.Lock()
+	c.token = body.AccessToken
+	c.refreshAt = refreshAt
+	c.mu.Unlock()
+
+	return body.AccessToken, nil
+}
+
+// authTransport applies credentials to each request sent to the origin
+// (scheme and host) they were configured for. Working at the transport level
+// means redirects to other hosts never carry credentials either.
+type authTransport struct {
+	base        http.RoundTripper
+	credentials map[string]Credentials
+}
+
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	credentials := t.credentials[origin(req.URL)]
+	if credentials == nil {
+		return base.RoundTrip(req)
+	}
+
+	// Round trippers must not modify the request, so work on a copy.
+	req = req.Clone(req.Context())
+	if err := credentials.Apply(req); err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, fmt.Errorf("failed to apply credentials: %w", err)
+	}
+	return base.RoundTrip(req)
+}
+
+// origin returns the normalized scheme and host of a URL.
+func origin(u *url.URL) string {
+	return strings.ToLower(u.Scheme + "://" + u.Host)
+}
+
+// withCredentials returns a copy of the client which applies credentials to
+// requests for the given base URLs. Invalid base URLs fail every request
+// anyway, so their credentials are simply never sent.
+func withCredentials(client *http.Client, credentials map[string]Credentials) *http.Client {
+	origins := map[string]Credentials{}
+	for baseURL, c := range credentials {
+		if u, err := url.Parse(baseURL); err == nil && c != nil {
+			origins[origin(u)] = c
+		}
+	}
+	if len(origins) == 0 {
+		return client
+	}
+
+	c := *client
+	c.Transport = &authTransport{
+		base:        client.Transport,
+		credentials: origins,
+	}
+	return &c
+}
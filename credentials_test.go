@@ -0,0 +1,167 @@
+package pokesdk_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/pokesdk"
+)
+
+func TestCredentials(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		credentials pokesdk.Credentials
+		header      string
+		expected    string
+	}{
+		{"bearer", pokesdk.BearerToken("abc123"), "Authorization", "Bearer abc123"},
+		{"api-key", pokesdk.APIKey{Key: "abc123"}, "X-API-Key", "abc123"},
+		{"api-key-header", pokesdk.APIKey{Header: "Api-Key", Key: "abc123"}, "Api-Key", "abc123"},
+		{"basic", pokesdk.BasicAuth{Username: "ash", Password: "pikachu"}, "Authorization", "Basic YXNoOnBpa2FjaHU="},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			transport := &mockTransport{}
+			transport.Expect("https://pokeapi.co/api/v2/pokemon/pikachu", http.StatusOK, `{"name":"pikachu"}`)
+			transport.Expect("https://other.example.com/api/v2/pokemon/pikachu", http.StatusOK, `{"name":"pikachu"}`)
+
+			sdk := pokesdk.New(pokesdk.Config{
+				Client:       &http.Client{Transport: transport},
+				Credentials:  test.credentials,
+				AllowedHosts: []string{"other.example.com"},
+			})
+
+			if _, err := sdk.GetPokemon(ctx, "pikachu"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if _, err := pokesdk.Follow[pokesdk.Pokemon](ctx, sdk, "https://other.example.com/api/v2/pokemon/pikachu"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if v := transport.requests[0].Header.Get(test.header); v != test.expected {
+				t.Errorf("expected %s header %q, got %q", test.header, test.expected, v)
+			}
+
+			if v := transport.requests[1].Header.Get(test.header); v != "" {
+				t.Errorf("expected no credentials for other host, got %q", v)
+			}
+		})
+	}
+}
+
+func TestClientCredentials(t *testing.T) {
+	ctx := context.Background()
+
+	var tokenRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, secret, _ := r.BasicAuth()
+		if id != "id" || secret != "secret" || r.FormValue("grant_type") != "client_credentials" || r.FormValue("scope") != "read write" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		tokenRequests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"tok","token_type":"bearer","expires_in":3600}`))
+	}))
+	defer server.Close()
+
+	transport := &mockTransport{}
+	for i := 0; i < 10; i++ {
+		transport.Expect("https://pokeapi.co/api/v2/pokemon/pikachu", http.StatusOK, `{"name":"pikachu"}`)
+	}
+
+	sdk := pokesdk.New(pokesdk.Config{
+		Client: &http.Client{Transport: transport},
+		Credentials: &pokesdk.ClientCredentials{
+			TokenURL:     server.URL,
+			ClientID:     "id",
+			ClientSecret: "secret",
+			Scopes:       []string{"read", "write"},
+		},
+	})
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := sdk.GetPokemon(ctx, "pikachu"); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := tokenRequests.Load(); n != 1 {
+		t.Errorf("expected a single token request, got %d", n)
+	}
+
+	for _, req := range transport.requests {
+		if v := req.Header.Get("Authorization"); v != "Bearer tok" {
+			t.Errorf("expected bearer token, got %q", v)
+		}
+	}
+}
+
+func TestClientCredentialsRefresh(t *testing.T) {
+	ctx := context.Background()
+
+	var tokenRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokenRequests.Add(1)
+		w.Write([]byte(`{"access_token":"tok","expires_in":1}`))
+	}))
+	defer server.Close()
+
+	// The refresh margin is capped at half of the one second lifetime.
+	creds := &pokesdk.ClientCredentials{TokenURL: server.URL}
+
+	for i := 0; i < 2; i++ {
+		if _, err := creds.Token(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if n := tokenRequests.Load(); n != 1 {
+		t.Errorf("expected cached token, got %d requests", n)
+	}
+
+	time.Sleep(600 * time.Millisecond)
+
+	if _, err := creds.Token(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if n := tokenRequests.Load(); n != 2 {
+		t.Errorf("expected token refresh, got %d requests", n)
+	}
+}
+
+func TestClientCredentialsError(t *testing.T) {
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	sdk := pokesdk.New(pokesdk.Config{
+		Client:      &http.Client{Transport: &mockTransport{}},
+		Credentials: &pokesdk.ClientCredentials{TokenURL: server.URL},
+	})
+
+	_, err := sdk.GetPokemon(ctx, "pikachu")
+	if !errors.Is(err, pokesdk.AuthError) {
+		t.Errorf("expected auth error, got %v", err)
+	}
+}
@@ -0,0 +1,52 @@
+package pokesdk
+
+// maxDriftRestarts limits how often `DriftRestart` starts over, so a list
+// which keeps changing can't make a paginator loop forever. Further drift is
+// handled like `DriftDedupe`.
+const maxDriftRestarts = 3
+
+// DriftPolicy decides what a paginator does when a list changes while it is
+// being iterated. Duplicates are detected using `NamedLink.URL`.
+type DriftPolicy int
+
+const (
+	// DriftWarn only reports drift via `Paginator.OnDrift` and
+	// `IteratorResult.Drift`, and still yields duplicates.
+	DriftWarn DriftPolicy = iota
+
+	// DriftDedupe drops items which were already yielded.
+	DriftDedupe
+
+	// DriftRestart starts over from the first page when drift is detected,
+	// yielding only items which were not yielded before, so items shifted to
+	// earlier pages are not missed. Results after a restart are flagged with
+	// `IteratorResult.Drift`, and their indexes continue from where the
+	// previous pass stopped.
+	DriftRestart
+)
+
+// Drift describes a change to a list detected while paginating.
+type Drift struct {
+	// Page is the URL of the page where drift was detected.
+	Page string
+
+	// PreviousCount and Count are the total counts before and after the
+	// change. They are equal when only a duplicate was found.
+	PreviousCount int
+	Count         int
+
+	// Duplicate is the URL of an item which was already seen on an earlier
+	// page, if any.
+	Duplicate string
+}
+
+// itemKey returns the key used to detect duplicate items.
+func itemKey(v any) (string, bool) {
+	switch v := v.(type) {
+	case NamedLink:
+		return v.URL, v.URL != ""
+	case *NamedLink:
+		return v.URL, v != nil && v.URL != ""
+	}
+	return "", false
+}
@@ -0,0 +1,207 @@
+package pokesdk_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/danielgtaylor/pokesdk"
+)
+
+// driftPage returns a page of pokemon with the given count and names.
+func driftPage(count int, next string, names ...string) string {
+	results := make([]string, 0, len(names))
+	for _, name := range names {
+		results = append(results, fmt.Sprintf(`{"name": %q, "url": "https://pokeapi.co/api/v2/pokemon/%s/"}`, name, name))
+	}
+	nextJSON := "null"
+	if next != "" {
+		nextJSON = fmt.Sprintf("%q", next)
+	}
+	return fmt.Sprintf(`{"count": %d, "next": %s, "results": [%s]}`, count, nextJSON, strings.Join(results, ","))
+}
+
+const driftPage2URL = "https://pokeapi.co/api/v2/pokemon?offset=2&limit=2"
+
+func collectDrift(t *testing.T, p *pokesdk.Paginator[pokesdk.NamedLink]) ([]string, []string) {
+	t.Helper()
+	names := []string{}
+	drifted := []string{}
+	for result := range p.All(context.Background()) {
+		if result.Error != nil {
+			t.Fatalf("unexpected error: %v", result.Error)
+		}
+		names = append(names, result.Value.Name)
+		if result.Drift {
+			drifted = append(drifted, result.Value.Name)
+		}
+	}
+	return names, drifted
+}
+
+func TestDriftCountChanged(t *testing.T) {
+	transport := &mockTransport{}
+	transport.Expect("https://pokeapi.co/api/v2/pokemon", http.StatusOK, driftPage(4, driftPage2URL, "bulbasaur", "ivysaur"))
+	transport.Expect(driftPage2URL, http.StatusOK, driftPage(5, "", "venusaur", "charmander"))
+
+	sdk := pokesdk.New(pokesdk.Config{
+		Client: &http.Client{Transport: transport},
+	})
+
+	drifts := []pokesdk.Drift{}
+	p := sdk.ListPokemon()
+	p.OnDrift = func(d pokesdk.Drift) {
+		drifts = append(drifts, d)
+	}
+
+	names, drifted := collectDrift(t, p)
+
+	if len(names) != 4 {
+		t.Errorf("expected 4 results, got %v", names)
+	}
+
+	if !reflect.DeepEqual(drifted, []string{"venusaur", "charmander"}) {
+		t.Errorf("expected second page to be flagged, got %v", drifted)
+	}
+
+	if len(drifts) != 1 || drifts[0].PreviousCount != 4 || drifts[0].Count != 5 || drifts[0].Page != driftPage2URL {
+		t.Errorf("unexpected drift: %+v", drifts)
+	}
+}
+
+func TestDriftDuplicates(t *testing.T) {
+	for name, policy := range map[string]pokesdk.DriftPolicy{"warn": pokesdk.DriftWarn, "dedupe": pokesdk.DriftDedupe} {
+		t.Run(name, func(t *testing.T) {
+			// An item was inserted at the start, shifting ivysaur onto the
+			// second page again.
+			transport := &mockTransport{}
+			transport.Expect("https://pokeapi.co/api/v2/pokemon", http.StatusOK, driftPage(4, driftPage2URL, "bulbasaur", "ivysaur"))
+			transport.Expect(driftPage2URL, http.StatusOK, driftPage(4, "", "ivysaur", "venusaur"))
+
+			sdk := pokesdk.New(pokesdk.Config{
+				Client: &http.Client{Transport: transport},
+			})
+
+			drifts := []pokesdk.Drift{}
+			p := sdk.ListPokemon()
+			p.DriftPolicy = policy
+			p.OnDrift = func(d pokesdk.Drift) {
+				drifts = append(drifts, d)
+			}
+
+			names, drifted := collectDrift(t, p)
+
+			expected := []string{"bulbasaur", "ivysaur", "venusaur"}
+			expectedDrifted := []string{}
+			if policy == pokesdk.DriftWarn {
+				expected = []string{"bulbasaur", "ivysaur", "ivysaur", "venusaur"}
+				expectedDrifted = []string{"ivysaur"}
+			}
+
+			if !reflect.DeepEqual(names, expected) {
+				t.Errorf("expected %v, got %v", expected, names)
+			}
+
+			if !reflect.DeepEqual(drifted, expectedDrifted) {
+				t.Errorf("expected drifted %v, got %v", expectedDrifted, drifted)
+			}
+
+			if len(drifts) != 1 || drifts[0].Duplicate != "https://pokeapi.co/api/v2/pokemon/ivysaur/" {
+				t.Errorf("unexpected drift: %+v", drifts)
+			}
+		})
+	}
+}
+
+func TestDriftRestart(t *testing.T) {
+	// Bulbasaur is removed after the first page, which shifts venusaur onto
+	// the first page where it would otherwise be missed.
+	transport := &mockTransport{}
+	transport.Expect("https://pokeapi.co/api/v2/pokemon", http.StatusOK, driftPage(4, driftPage2URL, "bulbasaur", "ivysaur"))
+	transport.Expect(driftPage2URL, http.StatusOK, driftPage(3, "", "charmander"))
+	transport.Expect("https://pokeapi.co/api/v2/pokemon", http.StatusOK, driftPage(3, driftPage2URL, "ivysaur", "venusaur"))
+	transport.Expect(driftPage2URL, http.StatusOK, driftPage(3, "", "charmander"))
+
+	sdk := pokesdk.New(pokesdk.Config{
+		Client: &http.Client{Transport: transport},
+	})
+
+	p := sdk.ListPokemon()
+	p.DriftPolicy = pokesdk.DriftRestart
+
+	names := []string{}
+	drifted := []string{}
+	indexes := map[int]bool{}
+	for result := range p.All(context.Background()) {
+		if result.Error != nil {
+			t.Fatalf("unexpected error: %v", result.Error)
+		}
+		names = append(names, result.Value.Name)
+		if result.Drift {
+			drifted = append(drifted, result.Value.Name)
+		}
+		if indexes[result.Index] {
+			t.Errorf("duplicate index %d for %s", result.Index, result.Value.Name)
+		}
+		indexes[result.Index] = true
+	}
+
+	if !reflect.DeepEqual(names, []string{"bulbasaur", "ivysaur", "venusaur", "charmander"}) {
+		t.Errorf("unexpected results: %v", names)
+	}
+
+	// Results after the restart are flagged.
+	if !reflect.DeepEqual(drifted, []string{"venusaur", "charmander"}) {
+		t.Errorf("unexpected drifted results: %v", drifted)
+	}
+}
+
+func TestDriftResume(t *testing.T) {
+	ctx := context.Background()
+
+	transport := &mockTransport{}
+	transport.Expect("https://pokeapi.co/api/v2/pokemon", http.StatusOK, driftPage(4, driftPage2URL, "bulbasaur", "ivysaur"))
+
+	sdk := pokesdk.New(pokesdk.Config{
+		Client: &http.Client{Transport: transport},
+	})
+
+	// Stop after the first page and save a checkpoint.
+	p := sdk.ListPokemon()
+	p.DriftPolicy = pokesdk.DriftDedupe
+	iter, cancel := p.AllWithCancel(ctx)
+	var saved []byte
+	for result := range iter {
+		if result.Value.Name == "ivysaur" {
+			saved, _ = json.Marshal(result.State)
+			cancel()
+			break
+		}
+	}
+
+	// Ivysaur was shifted onto the second page in the meantime, and must not
+	// be yielded again after resuming.
+	transport = &mockTransport{}
+	transport.Expect(driftPage2URL, http.StatusOK, driftPage(4, "", "ivysaur", "venusaur"))
+	sdk = pokesdk.New(pokesdk.Config{
+		Client: &http.Client{Transport: transport},
+	})
+
+	var state pokesdk.PaginatorState
+	if err := json.Unmarshal(saved, &state); err != nil {
+		t.Fatal(err)
+	}
+
+	p = sdk.ListPokemon()
+	p.DriftPolicy = pokesdk.DriftDedupe
+	p.Restore(state)
+
+	names, _ := collectDrift(t, p)
+	if !reflect.DeepEqual(names, []string{"venusaur"}) {
+		t.Errorf("unexpected results after resuming: %v", names)
+	}
+}
@@ -0,0 +1,107 @@
+package pokesdk
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+type Encounter struct {
+	MinLevel        int         `json:"min_level"`
+	MaxLevel        int         `json:"max_level"`
+	ConditionValues []NamedLink `json:"condition_values"`
+	Chance          int         `json:"chance"`
+	Method          NamedLink   `json:"method"`
+}
+
+type VersionEncounterDetail struct {
+	Version          VersionLink `json:"version"`
+	MaxChance        int         `json:"max_chance"`
+	EncounterDetails []Encounter `json:"encounter_details"`
+}
+
+// LocationAreaEncounter is a location area where a Pokemon can be encountered
+// along with the details of those encounters per game version.
+type LocationAreaEncounter struct {
+	LocationArea   NamedLink                `json:"location_area"`
+	VersionDetails []VersionEncounterDetail `json:"version_details"`
+}
+
+// GetEncounters returns the location areas where the given Pokemon can be
+// encountered, using its `LocationAreaEncounters` link.
+//
+//	encounters, err := sdk.GetEncounters(ctx, pika)
+func (s *SDK) GetEncounters(ctx context.Context, pokemon *Pokemon, opts ...CallOption) ([]LocationAreaEncounter, error) {
+	url := pokemon.LocationAreaEncounters
+	if url == "" {
+		return nil, fmt.Errorf("pokemon %s has no encounters link", pokemon.Name)
+	}
+
+	if strings.HasPrefix(url, "/") {
+		// Older API versions return a path rather than a full URL.
+		url = s.baseURL + url
+	}
+
+	encounters, err := Follow[[]LocationAreaEncounter](ctx, s, url, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if encounters == nil || *encounters == nil {
+		// A Pokemon which can't be encountered in the wild may have a `null`
+		// body rather than an empty list.
+		return []LocationAreaEncounter{}, nil
+	}
+	return *encounters, nil
+}
+
+// EncountersInVersion filters encounters down to the given game version,
+// answering "where can I catch this Pokemon in version X?"
+//
+//	for _, e := range pokesdk.EncountersInVersion(encounters, pokesdk.VersionRed) {
+//		fmt.Println(e.LocationArea.Name, e.VersionDetails[0].MaxChance)
+//	}
+func EncountersInVersion(encounters []LocationAreaEncounter, version VersionName) []LocationAreaEncounter {
+	filtered := []LocationAreaEncounter{}
+	for _, e := range encounters {
+		for _, d := range e.VersionDetails {
+			if d.Version.Name == version {
+				filtered = append(filtered, LocationAreaEncounter{
+					LocationArea:   e.LocationArea,
+					VersionDetails: []VersionEncounterDetail{d},
+				})
+				break
+			}
+		}
+	}
+	return filtered
+}
+
+// WhereToCatch returns the location areas where the named Pokemon can be
+// encountered in the given game version.
+//
+//	areas, err := sdk.WhereToCatch(ctx, "pikachu", pokesdk.VersionYellow)
+func (s *SDK) WhereToCatch(ctx context.Context, name string, version VersionName) ([]LocationAreaEncounter, error) {
+	pokemon, err := s.GetPokemon(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	encounters, err := s.GetEncounters(ctx, pokemon)
+	if err != nil {
+		return nil, err
+	}
+
+	return EncountersInVersion(encounters, version), nil
+}
+
+// CatchableIn returns the Pokemon that can be encountered in the named
+// location area in the given game version.
+//
+//	found, err := sdk.CatchableIn(ctx, "viridian-forest-area", pokesdk.VersionRed)
+func (s *SDK) CatchableIn(ctx context.Context, area string, version VersionName) ([]PokemonEncounter, error) {
+	a, err := s.GetLocationArea(ctx, area)
+	if err != nil {
+		return nil, err
+	}
+	return a.PokemonInVersion(version), nil
+}
@@ -0,0 +1,102 @@
+package pokesdk_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/danielgtaylor/pokesdk"
+)
+
+const pikachuEncounters = `[
+	{
+		"location_area": {"name": "viridian-forest-area", "url": ""},
+		"version_details": [
+			{"version": {"name": "yellow"}, "max_chance": 5, "encounter_details": [{"min_level": 3, "max_level": 5, "chance": 5, "method": {"name": "walk"}, "condition_values": []}]}
+		]
+	},
+	{
+		"location_area": {"name": "trophy-garden-area", "url": ""},
+		"version_details": [
+			{"version": {"name": "diamond"}, "max_chance": 10, "encounter_details": [{"min_level": 16, "max_level": 16, "chance": 10, "method": {"name": "walk"}, "condition_values": [{"name": "time-morning"}]}]}
+		]
+	}
+]`
+
+func TestGetEncounters(t *testing.T) {
+	ctx := context.Background()
+
+	transport := &mockTransport{}
+	transport.Expect("https://pokeapi.co/api/v2/pokemon/25/encounters", http.StatusOK, pikachuEncounters)
+
+	sdk := pokesdk.New(pokesdk.Config{
+		Client: &http.Client{Transport: transport},
+	})
+
+	encounters, err := sdk.GetEncounters(ctx, &pokesdk.Pokemon{
+		Name:                   "pikachu",
+		LocationAreaEncounters: "https://pokeapi.co/api/v2/pokemon/25/encounters",
+	})
+	if err != nil {
+		t.Fatalf("failed to get encounters: %v", err)
+	}
+
+	if len(encounters) != 2 {
+		t.Fatalf("expected 2 encounters, got %d", len(encounters))
+	}
+
+	detail := encounters[1].VersionDetails[0].EncounterDetails[0]
+	if detail.ConditionValues[0].Name != "time-morning" {
+		t.Errorf("unexpected conditions: %+v", detail.ConditionValues)
+	}
+
+	if _, err := sdk.GetEncounters(ctx, &pokesdk.Pokemon{Name: "missingno"}); err == nil {
+		t.Errorf("expected error without encounters link")
+	}
+}
+
+func TestGetEncountersNull(t *testing.T) {
+	transport := &mockTransport{}
+	transport.Expect("https://pokeapi.co/api/v2/pokemon/151/encounters", http.StatusOK, `null`)
+
+	sdk := pokesdk.New(pokesdk.Config{
+		Client: &http.Client{Transport: transport},
+	})
+
+	encounters, err := sdk.GetEncounters(context.Background(), &pokesdk.Pokemon{
+		Name:                   "mew",
+		LocationAreaEncounters: "https://pokeapi.co/api/v2/pokemon/151/encounters",
+	})
+	if err != nil {
+		t.Fatalf("failed to get encounters: %v", err)
+	}
+
+	if encounters == nil || len(encounters) != 0 {
+		t.Errorf("expected empty encounters, got %#v", encounters)
+	}
+}
+
+func TestWhereToCatch(t *testing.T) {
+	ctx := context.Background()
+
+	transport := &mockTransport{}
+	transport.Expect(
+		"https://pokeapi.co/api/v2/pokemon/pikachu",
+		http.StatusOK,
+		`{"name":"pikachu","location_area_encounters":"/api/v2/pokemon/25/encounters"}`,
+	)
+	transport.Expect("https://pokeapi.co/api/v2/pokemon/25/encounters", http.StatusOK, pikachuEncounters)
+
+	sdk := pokesdk.New(pokesdk.Config{
+		Client: &http.Client{Transport: transport},
+	})
+
+	areas, err := sdk.WhereToCatch(ctx, "pikachu", pokesdk.VersionDiamond)
+	if err != nil {
+		t.Fatalf("failed to find pikachu: %v", err)
+	}
+
+	if len(areas) != 1 || areas[0].LocationArea.Name != "trophy-garden-area" {
+		t.Errorf("expected trophy garden, got %+v", areas)
+	}
+}
@@ -0,0 +1,216 @@
+package pokesdk
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultEndpointFailureThreshold is the number of consecutive failures after
+// which an endpoint is considered unhealthy.
+var DefaultEndpointFailureThreshold = 3
+
+// DefaultEndpointCooldown is how long an unhealthy endpoint is avoided before
+// it is tried again.
+var DefaultEndpointCooldown = 30 * time.Second
+
+// EndpointPolicy selects the order in which `BaseURL` and `Config.Endpoints`
+// are tried. Unhealthy endpoints are always tried last.
+type EndpointPolicy int
+
+const (
+	// Failover prefers `BaseURL` and then each endpoint in the configured
+	// order.
+	Failover EndpointPolicy = iota
+
+	// RoundRobin spreads requests evenly across healthy endpoints.
+	RoundRobin
+
+	// LeastLatency prefers the healthy endpoint with the lowest recent
+	// latency. Endpoints without measurements are tried first.
+	LeastLatency
+)
+
+// Endpoint is an additional base URL serving the API, such as a regional
+// mirror or the public API as a fallback.
+type Endpoint struct {
+	URL string
+
+	// Credentials for this endpoint. `Config.Credentials` is only sent to
+	// `BaseURL`.
+	Credentials Credentials
+}
+
+// endpoint tracks the passive health of a base URL based on the results of
+// requests sent to it. Without `Config.CircuitBreaker` its circuit is only
+// used to try unhealthy endpoints last.
+type endpoint struct {
+	url     string
+	breaker *breaker
+
+	mu      sync.Mutex
+	latency time.Duration
+}
+
+func (e *endpoint) success(latency time.Duration) {
+	e.breaker.success()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.latency == 0 {
+		e.latency = latency
+	} else {
+		// Exponentially weighted moving average favoring recent requests.
+		e.latency = (e.latency*3 + latency) / 4
+	}
+}
+
+func (e *endpoint) averageLatency() time.Duration {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.latency
+}
+
+// endpointRouter picks endpoints for requests based on a policy.
+type endpointRouter struct {
+	endpoints []*endpoint
+	policy    EndpointPolicy
+	next      atomic.Uint64
+
+	// failFast skips endpoints with open circuits instead of trying them
+	// last.
+	failFast bool
+}
+
+// order returns the endpoints in the order they should be tried.
+func (r *endpointRouter) order() []*endpoint {
+	healthy := make([]*endpoint, 0, len(r.endpoints))
+	unhealthy := []*endpoint{}
+	for _, e := range r.endpoints {
+		if e.breaker.available() {
+			healthy = append(healthy, e)
+		} else {
+			unhealthy = append(unhealthy, e)
+		}
+	}
+
+	switch r.policy {
+	case RoundRobin:
+		if len(healthy) > 0 {
+			start := int((r.next.Add(1) - 1) % uint64(len(healthy)))
+			healthy = append(healthy[start:], healthy[:start]...)
+		}
+	case LeastLatency:
+		sort.SliceStable(healthy, func(i, j int) bool {
+			return healthy[i].averageLatency() < healthy[j].averageLatency()
+		})
+	}
+
+	return append(healthy, unhealthy...)
+}
+
+// match returns the path of a URL relative to whichever endpoint it is for.
+func (r *endpointRouter) match(u string) (string, bool) {
+	for _, e := range r.endpoints {
+		if rest, ok := cutBase(u, e.url); ok {
+			return rest, true
+		}
+	}
+	return "", false
+}
+
+// cutBase returns the rest of the URL after the base URL, only matching
+// whole path segments.
+func cutBase(u, base string) (string, bool) {
+	rest, ok := strings.CutPrefix(u, base)
+	if !ok {
+		return "", false
+	}
+	if rest != "" && !strings.HasSuffix(base, "/") && !strings.ContainsAny(rest[:1], "/?#") {
+		return "", false
+	}
+	return rest, true
+}
+
+// retryable returns whether a failed attempt means the endpoint is unhealthy
+// and the request should be tried elsewhere.
+func retryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+}
+
+// doEndpoints sends the request to each endpoint in turn until one succeeds,
+// tracking the health of each. The last response or error is returned if all
+// endpoints fail, or `ErrCircuitOpen` if circuit breaking is enabled and none
+// could be tried.
+func (s *SDK) doEndpoints(req *http.Request, rest string) (*http.Response, error) {
+	endpoints := s.router.order()
+
+	var resp *http.Response
+	var err error
+	tried := false
+	for _, e := range endpoints {
+		if !e.breaker.allow() && s.router.failFast {
+			continue
+		}
+		tried = true
+
+		if resp != nil {
+			// Only the last failed response is returned.
+			resp.Body.Close()
+		}
+
+		u, perr := url.Parse(e.url + rest)
+		if perr != nil {
+			e.breaker.release()
+			return nil, fmt.Errorf("failed to create request: %w", perr)
+		}
+
+		r := req.Clone(req.Context())
+		r.URL = u
+		r.Host = ""
+		if req.GetBody != nil {
+			if r.Body, err = req.GetBody(); err != nil {
+				e.breaker.release()
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+		}
+
+		start := time.Now()
+		resp, err = s.send(r)
+		if req.Context().Err() != nil {
+			// The caller gave up, which says nothing about the endpoint.
+			e.breaker.release()
+			return resp, err
+		}
+
+		if !retryable(resp, err) {
+			e.success(time.Since(start))
+			return resp, nil
+		}
+
+		e.breaker.failure()
+	}
+
+	if !tried {
+		return nil, fmt.Errorf("%w: no endpoint available for %s", ErrCircuitOpen, req.URL)
+	}
+
+	return resp, err
+}
+
+// CircuitStates returns the circuit state of each endpoint by base URL.
+func (s *SDK) CircuitStates() map[string]CircuitState {
+	states := make(map[string]CircuitState, len(s.router.endpoints))
+	for _, e := range s.router.endpoints {
+		states[e.url] = e.breaker.current()
+	}
+	return states
+}
@@ -0,0 +1,223 @@
+package pokesdk_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/pokesdk"
+)
+
+func TestEndpointFailover(t *testing.T) {
+	ctx := context.Background()
+
+	transport := &mockTransport{}
+	transport.Expect("https://eu.example.com/api/v2/pokemon/pikachu", http.StatusServiceUnavailable, ``)
+	for i := 0; i < 4; i++ {
+		transport.Expect("https://us.example.com/api/v2/pokemon/pikachu", http.StatusOK, `{"name":"pikachu"}`)
+	}
+
+	sdk := pokesdk.New(pokesdk.Config{
+		BaseURL: "https://eu.example.com",
+		Client:  &http.Client{Transport: transport},
+		Endpoints: []pokesdk.Endpoint{
+			{URL: "https://us.example.com"},
+			{URL: "https://pokeapi.co"},
+		},
+	})
+
+	// The first request fails over. After the EU mirror fails twice more it
+	// is unhealthy and skipped.
+	for i := 0; i < 4; i++ {
+		pika, err := sdk.GetPokemon(ctx, "pikachu")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if pika.Name != "pikachu" {
+			t.Errorf("expected pikachu, got %s", pika.Name)
+		}
+	}
+
+	hosts := []string{}
+	for _, req := range transport.requests {
+		hosts = append(hosts, req.URL.Host)
+	}
+
+	expected := []string{
+		"eu.example.com", "us.example.com",
+		"eu.example.com", "us.example.com",
+		"eu.example.com", "us.example.com",
+		"us.example.com",
+	}
+	if len(hosts) != len(expected) {
+		t.Fatalf("expected hosts %v, got %v", expected, hosts)
+	}
+	for i := range expected {
+		if hosts[i] != expected[i] {
+			t.Fatalf("expected hosts %v, got %v", expected, hosts)
+		}
+	}
+}
+
+func TestEndpointAllFail(t *testing.T) {
+	ctx := context.Background()
+
+	transport := &mockTransport{}
+	transport.Expect("https://eu.example.com/api/v2/pokemon/pikachu", http.StatusBadGateway, ``)
+	transport.Expect("https://us.example.com/api/v2/pokemon/pikachu", http.StatusServiceUnavailable, ``)
+
+	sdk := pokesdk.New(pokesdk.Config{
+		BaseURL:   "https://eu.example.com",
+		Client:    &http.Client{Transport: transport},
+		Endpoints: []pokesdk.Endpoint{{URL: "https://us.example.com"}},
+	})
+
+	_, resp, err := sdk.GetPokemonWithResponse(ctx, "pikachu")
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected last response, got %d", resp.StatusCode)
+	}
+}
+
+func TestEndpointRoundRobin(t *testing.T) {
+	ctx := context.Background()
+
+	transport := &mockTransport{}
+	for i := 0; i < 2; i++ {
+		transport.Expect("https://eu.example.com/api/v2/pokemon/pikachu", http.StatusOK, `{"name":"pikachu"}`)
+		transport.Expect("https://us.example.com/api/v2/pokemon/pikachu", http.StatusOK, `{"name":"pikachu"}`)
+	}
+
+	sdk := pokesdk.New(pokesdk.Config{
+		BaseURL:        "https://eu.example.com",
+		Client:         &http.Client{Transport: transport},
+		Endpoints:      []pokesdk.Endpoint{{URL: "https://us.example.com"}},
+		EndpointPolicy: pokesdk.RoundRobin,
+	})
+
+	for i := 0; i < 4; i++ {
+		if _, err := sdk.GetPokemon(ctx, "pikachu"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	for i, req := range transport.requests {
+		expected := []string{"eu.example.com", "us.example.com"}[i%2]
+		if req.URL.Host != expected {
+			t.Errorf("expected request %d to go to %s, got %s", i, expected, req.URL.Host)
+		}
+	}
+}
+
+// slowTransport delays requests to one host.
+type slowTransport struct {
+	*mockTransport
+	host  string
+	delay time.Duration
+}
+
+func (t slowTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Host == t.host {
+		time.Sleep(t.delay)
+	}
+	return t.mockTransport.RoundTrip(req)
+}
+
+func TestEndpointLeastLatency(t *testing.T) {
+	ctx := context.Background()
+
+	transport := &mockTransport{}
+	transport.Expect("https://eu.example.com/api/v2/pokemon/pikachu", http.StatusOK, `{"name":"pikachu"}`)
+	for i := 0; i < 2; i++ {
+		transport.Expect("https://us.example.com/api/v2/pokemon/pikachu", http.StatusOK, `{"name":"pikachu"}`)
+	}
+
+	sdk := pokesdk.New(pokesdk.Config{
+		BaseURL:        "https://eu.example.com",
+		Client:         &http.Client{Transport: slowTransport{transport, "eu.example.com", 20 * time.Millisecond}},
+		Endpoints:      []pokesdk.Endpoint{{URL: "https://us.example.com"}},
+		EndpointPolicy: pokesdk.LeastLatency,
+	})
+
+	for i := 0; i < 3; i++ {
+		if _, err := sdk.GetPokemon(ctx, "pikachu"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// The US mirror has no measurements after the first request so it is
+	// tried next, and is faster.
+	for i, expected := range []string{"eu.example.com", "us.example.com", "us.example.com"} {
+		if host := transport.requests[i].URL.Host; host != expected {
+			t.Errorf("expected request %d to go to %s, got %s", i, expected, host)
+		}
+	}
+}
+
+func TestEndpointPaginatorNext(t *testing.T) {
+	ctx := context.Background()
+
+	transport := &mockTransport{}
+	transport.Expect("https://eu.example.com/api/v2/pokemon", http.StatusOK, `{
+		"count": 2,
+		"next": "https://us.example.com/api/v2/pokemon?offset=1&limit=1",
+		"results": [{"name": "bulbasaur", "url": "https://eu.example.com/api/v2/pokemon/1/"}]
+	}`)
+	// The next link is for the US mirror, but it is routed like any other
+	// request so it goes to the primary endpoint.
+	transport.Expect("https://eu.example.com/api/v2/pokemon?offset=1&limit=1", http.StatusOK, `{
+		"count": 2,
+		"results": [{"name": "ivysaur", "url": "https://eu.example.com/api/v2/pokemon/2/"}]
+	}`)
+
+	sdk := pokesdk.New(pokesdk.Config{
+		BaseURL:   "https://eu.example.com",
+		Client:    &http.Client{Transport: transport},
+		Endpoints: []pokesdk.Endpoint{{URL: "https://us.example.com"}},
+	})
+
+	count := 0
+	for result := range sdk.ListPokemon().All(ctx) {
+		if result.Error != nil {
+			t.Fatalf("unexpected error: %v", result.Error)
+		}
+		count++
+	}
+
+	if count != 2 {
+		t.Errorf("expected 2 results, got %d", count)
+	}
+}
+
+func TestEndpointCredentials(t *testing.T) {
+	ctx := context.Background()
+
+	transport := &mockTransport{}
+	transport.Expect("https://eu.example.com/api/v2/pokemon/pikachu", http.StatusInternalServerError, ``)
+	transport.Expect("https://us.example.com/api/v2/pokemon/pikachu", http.StatusInternalServerError, ``)
+	transport.Expect("https://pokeapi.co/api/v2/pokemon/pikachu", http.StatusOK, `{"name":"pikachu"}`)
+
+	sdk := pokesdk.New(pokesdk.Config{
+		BaseURL:     "https://eu.example.com",
+		Client:      &http.Client{Transport: transport},
+		Credentials: pokesdk.BearerToken("eu"),
+		Endpoints: []pokesdk.Endpoint{
+			{URL: "https://us.example.com", Credentials: pokesdk.BearerToken("us")},
+			{URL: "https://pokeapi.co"},
+		},
+	})
+
+	if _, err := sdk.GetPokemon(ctx, "pikachu"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for i, expected := range []string{"Bearer eu", "Bearer us", ""} {
+		if auth := transport.requests[i].Header.Get("Authorization"); auth != expected {
+			t.Errorf("expected request %d to have auth %q, got %q", i, expected, auth)
+		}
+	}
+}
@@ -0,0 +1,273 @@
+// Code generated by genenums; DO NOT EDIT.
+
+package pokesdk
+
+// StatName is the name of a stat, e.g. `StatHP`.
+// Unknown names from newer API versions are preserved when decoding.
+type StatName string
+
+const (
+	StatHP             StatName = "hp"
+	StatAttack         StatName = "attack"
+	StatDefense        StatName = "defense"
+	StatSpecialAttack  StatName = "special-attack"
+	StatSpecialDefense StatName = "special-defense"
+	StatSpeed          StatName = "speed"
+	StatAccuracy       StatName = "accuracy"
+	StatEvasion        StatName = "evasion"
+)
+
+// StatNames lists all known StatName values.
+var StatNames = []StatName{
+	StatHP,
+	StatAttack,
+	StatDefense,
+	StatSpecialAttack,
+	StatSpecialDefense,
+	StatSpeed,
+	StatAccuracy,
+	StatEvasion,
+}
+
+// Known returns whether the name is one of the generated constants.
+func (n StatName) Known() bool {
+	switch n {
+	case StatHP, StatAttack, StatDefense, StatSpecialAttack, StatSpecialDefense, StatSpeed, StatAccuracy, StatEvasion:
+		return true
+	}
+	return false
+}
+
+// StatLink is a link to a stat, e.g. `StatHP`.
+type StatLink struct {
+	Name StatName `json:"name"`
+	URL  string   `json:"url"`
+}
+
+// TypeName is the name of an elemental type, e.g. `TypeElectric`.
+// Unknown names from newer API versions are preserved when decoding.
+type TypeName string
+
+const (
+	TypeNormal   TypeName = "normal"
+	TypeFighting TypeName = "fighting"
+	TypeFlying   TypeName = "flying"
+	TypePoison   TypeName = "poison"
+	TypeGround   TypeName = "ground"
+	TypeRock     TypeName = "rock"
+	TypeBug      TypeName = "bug"
+	TypeGhost    TypeName = "ghost"
+	TypeSteel    TypeName = "steel"
+	TypeFire     TypeName = "fire"
+	TypeWater    TypeName = "water"
+	TypeGrass    TypeName = "grass"
+	TypeElectric TypeName = "electric"
+	TypePsychic  TypeName = "psychic"
+	TypeIce      TypeName = "ice"
+	TypeDragon   TypeName = "dragon"
+	TypeDark     TypeName = "dark"
+	TypeFairy    TypeName = "fairy"
+	TypeStellar  TypeName = "stellar"
+	TypeUnknown  TypeName = "unknown"
+	TypeShadow   TypeName = "shadow"
+)
+
+// TypeNames lists all known TypeName values.
+var TypeNames = []TypeName{
+	TypeNormal,
+	TypeFighting,
+	TypeFlying,
+	TypePoison,
+	TypeGround,
+	TypeRock,
+	TypeBug,
+	TypeGhost,
+	TypeSteel,
+	TypeFire,
+	TypeWater,
+	TypeGrass,
+	TypeElectric,
+	TypePsychic,
+	TypeIce,
+	TypeDragon,
+	TypeDark,
+	TypeFairy,
+	TypeStellar,
+	TypeUnknown,
+	TypeShadow,
+}
+
+// Known returns whether the name is one of the generated constants.
+func (n TypeName) Known() bool {
+	switch n {
+	case TypeNormal, TypeFighting, TypeFlying, TypePoison, TypeGround, TypeRock, TypeBug, TypeGhost, TypeSteel, TypeFire, TypeWater, TypeGrass, TypeElectric, TypePsychic, TypeIce, TypeDragon, TypeDark, TypeFairy, TypeStellar, TypeUnknown, TypeShadow:
+		return true
+	}
+	return false
+}
+
+// TypeLink is a link to an elemental type, e.g. `TypeElectric`.
+type TypeLink struct {
+	Name TypeName `json:"name"`
+	URL  string   `json:"url"`
+}
+
+// LearnMethodName is the name of a move learn method, e.g. `LearnMethodLevelUp`.
+// Unknown names from newer API versions are preserved when decoding.
+type LearnMethodName string
+
+const (
+	LearnMethodLevelUp               LearnMethodName = "level-up"
+	LearnMethodEgg                   LearnMethodName = "egg"
+	LearnMethodTutor                 LearnMethodName = "tutor"
+	LearnMethodMachine               LearnMethodName = "machine"
+	LearnMethodStadiumSurfingPikachu LearnMethodName = "stadium-surfing-pikachu"
+	LearnMethodLightBallEgg          LearnMethodName = "light-ball-egg"
+	LearnMethodColosseumPurification LearnMethodName = "colosseum-purification"
+	LearnMethodXDShadow              LearnMethodName = "xd-shadow"
+	LearnMethodXDPurification        LearnMethodName = "xd-purification"
+	LearnMethodFormChange            LearnMethodName = "form-change"
+	LearnMethodZygardeCube           LearnMethodName = "zygarde-cube"
+)
+
+// LearnMethodNames lists all known LearnMethodName values.
+var LearnMethodNames = []LearnMethodName{
+	LearnMethodLevelUp,
+	LearnMethodEgg,
+	LearnMethodTutor,
+	LearnMethodMachine,
+	LearnMethodStadiumSurfingPikachu,
+	LearnMethodLightBallEgg,
+	LearnMethodColosseumPurification,
+	LearnMethodXDShadow,
+	LearnMethodXDPurification,
+	LearnMethodFormChange,
+	LearnMethodZygardeCube,
+}
+
+// Known returns whether the name is one of the generated constants.
+func (n LearnMethodName) Known() bool {
+	switch n {
+	case LearnMethodLevelUp, LearnMethodEgg, LearnMethodTutor, LearnMethodMachine, LearnMethodStadiumSurfingPikachu, LearnMethodLightBallEgg, LearnMethodColosseumPurification, LearnMethodXDShadow, LearnMethodXDPurification, LearnMethodFormChange, LearnMethodZygardeCube:
+		return true
+	}
+	return false
+}
+
+// LearnMethodLink is a link to a move learn method, e.g. `LearnMethodLevelUp`.
+type LearnMethodLink struct {
+	Name LearnMethodName `json:"name"`
+	URL  string          `json:"url"`
+}
+
+// VersionName is the name of a game version, e.g. `VersionRed`.
+// Unknown names from newer API versions are preserved when decoding.
+type VersionName string
+
+const (
+	VersionRed              VersionName = "red"
+	VersionBlue             VersionName = "blue"
+	VersionYellow           VersionName = "yellow"
+	VersionGold             VersionName = "gold"
+	VersionSilver           VersionName = "silver"
+	VersionCrystal          VersionName = "crystal"
+	VersionRuby             VersionName = "ruby"
+	VersionSapphire         VersionName = "sapphire"
+	VersionEmerald          VersionName = "emerald"
+	VersionFirered          VersionName = "firered"
+	VersionLeafgreen        VersionName = "leafgreen"
+	VersionDiamond          VersionName = "diamond"
+	VersionPearl            VersionName = "pearl"
+	VersionPlatinum         VersionName = "platinum"
+	VersionHeartgold        VersionName = "heartgold"
+	VersionSoulsilver       VersionName = "soulsilver"
+	VersionBlack            VersionName = "black"
+	VersionWhite            VersionName = "white"
+	VersionColosseum        VersionName = "colosseum"
+	VersionXD               VersionName = "xd"
+	VersionBlack2           VersionName = "black-2"
+	VersionWhite2           VersionName = "white-2"
+	VersionX                VersionName = "x"
+	VersionY                VersionName = "y"
+	VersionOmegaRuby        VersionName = "omega-ruby"
+	VersionAlphaSapphire    VersionName = "alpha-sapphire"
+	VersionSun              VersionName = "sun"
+	VersionMoon             VersionName = "moon"
+	VersionUltraSun         VersionName = "ultra-sun"
+	VersionUltraMoon        VersionName = "ultra-moon"
+	VersionLetsGoPikachu    VersionName = "lets-go-pikachu"
+	VersionLetsGoEevee      VersionName = "lets-go-eevee"
+	VersionSword            VersionName = "sword"
+	VersionShield           VersionName = "shield"
+	VersionTheIsleOfArmor   VersionName = "the-isle-of-armor"
+	VersionTheCrownTundra   VersionName = "the-crown-tundra"
+	VersionBrilliantDiamond VersionName = "brilliant-diamond"
+	VersionShiningPearl     VersionName = "shining-pearl"
+	VersionLegendsArceus    VersionName = "legends-arceus"
+	VersionScarlet          VersionName = "scarlet"
+	VersionViolet           VersionName = "violet"
+	VersionTheTealMask      VersionName = "the-teal-mask"
+	VersionTheIndigoDisk    VersionName = "the-indigo-disk"
+)
+
+// VersionNames lists all known VersionName values.
+var VersionNames = []VersionName{
+	VersionRed,
+	VersionBlue,
+	VersionYellow,
+	VersionGold,
+	VersionSilver,
+	VersionCrystal,
+	VersionRuby,
+	VersionSapphire,
+	VersionEmerald,
+	VersionFirered,
+	VersionLeafgreen,
+	VersionDiamond,
+	VersionPearl,
+	VersionPlatinum,
+	VersionHeartgold,
+	VersionSoulsilver,
+	VersionBlack,
+	VersionWhite,
+	VersionColosseum,
+	VersionXD,
+	VersionBlack2,
+	VersionWhite2,
+	VersionX,
+	VersionY,
+	VersionOmegaRuby,
+	VersionAlphaSapphire,
+	VersionSun,
+	VersionMoon,
+	VersionUltraSun,
+	VersionUltraMoon,
+	VersionLetsGoPikachu,
+	VersionLetsGoEevee,
+	VersionSword,
+	VersionShield,
+	VersionTheIsleOfArmor,
+	VersionTheCrownTundra,
+	VersionBrilliantDiamond,
+	VersionShiningPearl,
+	VersionLegendsArceus,
+	VersionScarlet,
+	VersionViolet,
+	VersionTheTealMask,
+	VersionTheIndigoDisk,
+}
+
+// Known returns whether the name is one of the generated constants.
+func (n VersionName) Known() bool {
+	switch n {
+	case VersionRed, VersionBlue, VersionYellow, VersionGold, VersionSilver, VersionCrystal, VersionRuby, VersionSapphire, VersionEmerald, VersionFirered, VersionLeafgreen, VersionDiamond, VersionPearl, VersionPlatinum, VersionHeartgold, VersionSoulsilver, VersionBlack, VersionWhite, VersionColosseum, VersionXD, VersionBlack2, VersionWhite2, VersionX, VersionY, VersionOmegaRuby, VersionAlphaSapphire, VersionSun, VersionMoon, VersionUltraSun, VersionUltraMoon, VersionLetsGoPikachu, VersionLetsGoEevee, VersionSword, VersionShield, VersionTheIsleOfArmor, VersionTheCrownTundra, VersionBrilliantDiamond, VersionShiningPearl, VersionLegendsArceus, VersionScarlet, VersionViolet, VersionTheTealMask, VersionTheIndigoDisk:
+		return true
+	}
+	return false
+}
+
+// VersionLink is a link to a game version, e.g. `VersionRed`.
+type VersionLink struct {
+	Name VersionName `json:"name"`
+	URL  string      `json:"url"`
+}
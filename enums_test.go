@@ -0,0 +1,59 @@
+package pokesdk_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/danielgtaylor/pokesdk"
+)
+
+const typedPokemon = `{"name":"pikachu","stats":[{"base_stat":90,"effort":2,"stat":{"name":"speed","url":"https://pokeapi.co/api/v2/stat/6/"}}],"types":[{"slot":1,"type":{"name":"electric","url":"https://pokeapi.co/api/v2/type/13/"}},{"slot":2,"type":{"name":"cosmic","url":"https://pokeapi.co/api/v2/type/99/"}}],"moves":[{"move":{"name":"thunder-shock","url":""},"version_group_details":[{"level_learned_at":1,"move_learn_method":{"name":"level-up","url":""},"version_group":{"name":"red-blue","url":""}}]}]}`
+
+func TestTypedEnums(t *testing.T) {
+	var pika pokesdk.Pokemon
+	if err := json.Unmarshal([]byte(typedPokemon), &pika); err != nil {
+		t.Fatal(err)
+	}
+
+	if pika.Stats[0].Stat.Name != pokesdk.StatSpeed {
+		t.Errorf("expected speed stat, got %s", pika.Stats[0].Stat.Name)
+	}
+
+	if pika.Types[0].Type.Name != pokesdk.TypeElectric || !pika.Types[0].Type.Name.Known() {
+		t.Errorf("expected known electric type, got %s", pika.Types[0].Type.Name)
+	}
+
+	if pika.Moves[0].VersionGroupDetails[0].MoveLearnMethod.Name != pokesdk.LearnMethodLevelUp {
+		t.Errorf("expected level-up method")
+	}
+
+	// Unknown values must survive a round trip.
+	if pika.Types[1].Type.Name != "cosmic" || pika.Types[1].Type.Name.Known() {
+		t.Errorf("expected unknown cosmic type, got %s", pika.Types[1].Type.Name)
+	}
+
+	b, err := json.Marshal(pika)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), `"name":"cosmic"`) {
+		t.Errorf("expected unknown type to be preserved: %s", b)
+	}
+}
+
+func TestEnumLists(t *testing.T) {
+	if len(pokesdk.StatNames) == 0 || len(pokesdk.TypeNames) == 0 || len(pokesdk.LearnMethodNames) == 0 || len(pokesdk.VersionNames) == 0 {
+		t.Fatalf("expected generated enum lists")
+	}
+
+	for _, v := range pokesdk.VersionNames {
+		if !v.Known() {
+			t.Errorf("expected %s to be known", v)
+		}
+	}
+
+	if !pokesdk.VersionRed.Known() || pokesdk.VersionName("red-japan").Known() {
+		t.Errorf("unexpected version known state")
+	}
+}
@@ -0,0 +1,181 @@
+package pokesdk
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// String returns a short human-readable description of the evolution trigger
+// and its conditions, e.g. `level-up (min level 16)`.
+func (d EvolutionDetail) String() string {
+	conditions := []string{}
+	add := func(format string, args ...any) {
+		conditions = append(conditions, fmt.Sprintf(format, args...))
+	}
+
+	if d.MinLevel != nil {
+		add("min level %d", *d.MinLevel)
+	}
+	if d.Item != nil {
+		add("item %s", d.Item.Name)
+	}
+	if d.HeldItem != nil {
+		add("holding %s", d.HeldItem.Name)
+	}
+	if d.MinHappiness != nil {
+		add("min happiness %d", *d.MinHappiness)
+	}
+	if d.MinBeauty != nil {
+		add("min beauty %d", *d.MinBeauty)
+	}
+	if d.MinAffection != nil {
+		add("min affection %d", *d.MinAffection)
+	}
+	if d.KnownMove != nil {
+		add("knows %s", d.KnownMove.Name)
+	}
+	if d.KnownMoveType != nil {
+		add("knows %s move", d.KnownMoveType.Name)
+	}
+	if d.Location != nil {
+		add("at %s", d.Location.Name)
+	}
+	if d.TimeOfDay != "" {
+		add("during %s", d.TimeOfDay)
+	}
+	if d.Gender != nil {
+		add("gender %d", *d.Gender)
+	}
+	if d.PartySpecies != nil {
+		add("with %s in party", d.PartySpecies.Name)
+	}
+	if d.PartyType != nil {
+		add("with %s type in party", d.PartyType.Name)
+	}
+	if d.TradeSpecies != nil {
+		add("traded for %s", d.TradeSpecies.Name)
+	}
+	if d.RelativePhysicalStats != nil {
+		add("relative physical stats %d", *d.RelativePhysicalStats)
+	}
+	if d.NeedsOverworldRain {
+		add("raining")
+	}
+	if d.TurnUpsideDown {
+		add("upside down")
+	}
+
+	if len(conditions) == 0 {
+		return d.Trigger.Name
+	}
+	return d.Trigger.Name + " (" + strings.Join(conditions, ", ") + ")"
+}
+
+// Find returns the node for the given species within this part of the chain,
+// or nil if the species is not part of it.
+func (c *ChainLink) Find(species string) *ChainLink {
+	if c.Species.Name == species {
+		return c
+	}
+	for _, next := range c.EvolvesTo {
+		if found := next.Find(species); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// PreEvolutionOf returns the node the given species evolves from, or nil if
+// it is the base form or not part of this chain.
+func (c *ChainLink) PreEvolutionOf(species string) *ChainLink {
+	for _, next := range c.EvolvesTo {
+		if next.Species.Name == species {
+			return c
+		}
+		if found := next.PreEvolutionOf(species); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// FinalForms returns the nodes which do not evolve any further and can be
+// reached from this node. A node which does not evolve is its own final form.
+func (c *ChainLink) FinalForms() []*ChainLink {
+	if len(c.EvolvesTo) == 0 {
+		return []*ChainLink{c}
+	}
+
+	forms := []*ChainLink{}
+	for _, next := range c.EvolvesTo {
+		forms = append(forms, next.FinalForms()...)
+	}
+	return forms
+}
+
+// Paths flattens the tree into every evolution path from this node to a final
+// form, e.g. `[eevee vaporeon]`, `[eevee jolteon]`, etc.
+func (c *ChainLink) Paths() [][]*ChainLink {
+	if len(c.EvolvesTo) == 0 {
+		return [][]*ChainLink{{c}}
+	}
+
+	paths := [][]*ChainLink{}
+	for _, next := range c.EvolvesTo {
+		for _, path := range next.Paths() {
+			paths = append(paths, append([]*ChainLink{c}, path...))
+		}
+	}
+	return paths
+}
+
+// EvolutionsOf returns the full evolution chain containing the given species.
+// The species name usually matches the name of its default Pokemon.
+//
+//	chain, err := sdk.EvolutionsOf(ctx, "eevee")
+//	for _, path := range chain.Chain.Paths() {
+//		fmt.Println(path[len(path)-1].Species.Name, path[len(path)-1].EvolutionDetails)
+//	}
+func (s *SDK) EvolutionsOf(ctx context.Context, species string) (*EvolutionChain, error) {
+	sp, err := s.GetPokemonSpecies(ctx, species)
+	if err != nil {
+		return nil, err
+	}
+
+	if sp.EvolutionChain.URL == "" {
+		return nil, fmt.Errorf("species %s has no evolution chain", species)
+	}
+
+	return Follow[EvolutionChain](ctx, s, sp.EvolutionChain.URL)
+}
+
+// PreEvolutionOf returns the species the given species evolves from, or nil
+// if it does not evolve from another species.
+func (s *SDK) PreEvolutionOf(ctx context.Context, species string) (*NamedLink, error) {
+	sp, err := s.GetPokemonSpecies(ctx, species)
+	if err != nil {
+		return nil, err
+	}
+	return sp.EvolvesFromSpecies, nil
+}
+
+// FinalFormsOf returns the species which the given species can ultimately
+// evolve into. A species which does not evolve is its own final form.
+func (s *SDK) FinalFormsOf(ctx context.Context, species string) ([]NamedLink, error) {
+	chain, err := s.EvolutionsOf(ctx, species)
+	if err != nil {
+		return nil, err
+	}
+
+	node := chain.Chain.Find(species)
+	if node == nil {
+		return nil, fmt.Errorf("species %s not found in evolution chain %d", species, chain.ID)
+	}
+
+	forms := []NamedLink{}
+	for _, final := range node.FinalForms() {
+		forms = append(forms, final.Species)
+	}
+	return forms, nil
+}
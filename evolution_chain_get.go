@@ -0,0 +1,72 @@
+package pokesdk
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+)
+
+// EvolutionDetail describes the trigger and conditions for an evolution.
+// Conditions which do not apply are nil.
+type EvolutionDetail struct {
+	Trigger               NamedLink  `json:"trigger"`
+	Item                  *NamedLink `json:"item"`
+	Gender                *int       `json:"gender"`
+	HeldItem              *NamedLink `json:"held_item"`
+	KnownMove             *NamedLink `json:"known_move"`
+	KnownMoveType         *TypeLink  `json:"known_move_type"`
+	Location              *NamedLink `json:"location"`
+	MinLevel              *int       `json:"min_level"`
+	MinHappiness          *int       `json:"min_happiness"`
+	MinBeauty             *int       `json:"min_beauty"`
+	MinAffection          *int       `json:"min_affection"`
+	NeedsOverworldRain    bool       `json:"needs_overworld_rain"`
+	PartySpecies          *NamedLink `json:"party_species"`
+	PartyType             *TypeLink  `json:"party_type"`
+	RelativePhysicalStats *int       `json:"relative_physical_stats"`
+	TimeOfDay             string     `json:"time_of_day"`
+	TradeSpecies          *NamedLink `json:"trade_species"`
+	TurnUpsideDown        bool       `json:"turn_upside_down"`
+}
+
+// ChainLink is a single node in an evolution chain. It links a species to the
+// species it can evolve into and how.
+type ChainLink struct {
+	IsBaby           bool              `json:"is_baby"`
+	Species          NamedLink         `json:"species"`
+	EvolutionDetails []EvolutionDetail `json:"evolution_details"`
+	EvolvesTo        []*ChainLink      `json:"evolves_to"`
+}
+
+// EvolutionChain is a family of Pokemon species related through evolution.
+type EvolutionChain struct {
+	ID              int        `json:"id"`
+	BabyTriggerItem *NamedLink `json:"baby_trigger_item"`
+	Chain           *ChainLink `json:"chain"`
+
+	// Extra holds unknown fields, see `Config.KeepUnknownFields`.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// MarshalJSON encodes the EvolutionChain including any unknown fields in `Extra`.
+func (c EvolutionChain) MarshalJSON() ([]byte, error) {
+	type plain EvolutionChain
+	return marshalWithExtra(plain(c), c.Extra)
+}
+
+// GetEvolutionChain returns a single EvolutionChain from the API. Evolution
+// chains have no names, so they are looked up by ID.
+//
+//	chain, err := sdk.GetEvolutionChain(ctx, 67)
+func (s *SDK) GetEvolutionChain(ctx context.Context, id int, opts ...CallOption) (*EvolutionChain, error) {
+	value, _, err := s.GetEvolutionChainWithResponse(ctx, id, opts...)
+	return value, err
+}
+
+// GetEvolutionChainWithResponse is like `GetEvolutionChain` but also returns metadata about the
+// response. See `FollowWithResponse`.
+//
+//	chain, resp, err := sdk.GetEvolutionChainWithResponse(ctx, 67)
+func (s *SDK) GetEvolutionChainWithResponse(ctx context.Context, id int, opts ...CallOption) (*EvolutionChain, *Response, error) {
+	return FollowWithResponse[EvolutionChain](ctx, s, s.baseURL+"/api/v2/evolution-chain/"+strconv.Itoa(id), opts...)
+}
@@ -0,0 +1,55 @@
+package pokesdk_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/danielgtaylor/pokesdk"
+)
+
+const charmanderChain = `{
+	"id": 2,
+	"baby_trigger_item": null,
+	"chain": {
+		"is_baby": false,
+		"species": {"name": "charmander", "url": ""},
+		"evolution_details": [],
+		"evolves_to": [{
+			"is_baby": false,
+			"species": {"name": "charmeleon", "url": ""},
+			"evolution_details": [{"trigger": {"name": "level-up", "url": ""}, "min_level": 16, "item": null}],
+			"evolves_to": [{
+				"is_baby": false,
+				"species": {"name": "charizard", "url": ""},
+				"evolution_details": [{"trigger": {"name": "level-up", "url": ""}, "min_level": 36}],
+				"evolves_to": []
+			}]
+		}]
+	}
+}`
+
+func TestGetEvolutionChain(t *testing.T) {
+	ctx := context.Background()
+
+	transport := &mockTransport{}
+	transport.Expect("https://pokeapi.co/api/v2/evolution-chain/2", http.StatusOK, charmanderChain)
+
+	sdk := pokesdk.New(pokesdk.Config{
+		Client: &http.Client{Transport: transport},
+	})
+
+	chain, err := sdk.GetEvolutionChain(ctx, 2)
+	if err != nil {
+		t.Fatalf("failed to get evolution chain: %v", err)
+	}
+
+	if chain.Chain.Species.Name != "charmander" {
+		t.Errorf("expected charmander, got %s", chain.Chain.Species.Name)
+	}
+
+	charmeleon := chain.Chain.EvolvesTo[0]
+	if d := charmeleon.EvolutionDetails[0]; d.MinLevel == nil || *d.MinLevel != 16 || d.Item != nil {
+		t.Errorf("unexpected evolution details: %+v", d)
+	}
+}
@@ -0,0 +1,138 @@
+package pokesdk_test
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/danielgtaylor/pokesdk"
+)
+
+const eeveeChain = `{
+	"id": 67,
+	"chain": {
+		"species": {"name": "eevee", "url": ""},
+		"evolution_details": [],
+		"evolves_to": [
+			{
+				"species": {"name": "vaporeon", "url": ""},
+				"evolution_details": [{"trigger": {"name": "use-item"}, "item": {"name": "water-stone"}}],
+				"evolves_to": []
+			},
+			{
+				"species": {"name": "espeon", "url": ""},
+				"evolution_details": [{"trigger": {"name": "level-up"}, "min_happiness": 160, "time_of_day": "day"}],
+				"evolves_to": []
+			},
+			{
+				"species": {"name": "sylveon", "url": ""},
+				"evolution_details": [{"trigger": {"name": "level-up"}, "known_move_type": {"name": "fairy"}, "min_affection": 2}],
+				"evolves_to": []
+			}
+		]
+	}
+}`
+
+func evolutionSDK() *pokesdk.SDK {
+	transport := &mockTransport{}
+	for _, name := range []string{"eevee", "espeon"} {
+		transport.Expect(
+			"https://pokeapi.co/api/v2/pokemon-species/"+name,
+			http.StatusOK,
+			`{"name":"`+name+`","evolution_chain":{"url":"https://pokeapi.co/api/v2/evolution-chain/67/"}}`,
+		)
+		transport.Expect("https://pokeapi.co/api/v2/evolution-chain/67/", http.StatusOK, eeveeChain)
+	}
+	transport.Expect(
+		"https://pokeapi.co/api/v2/pokemon-species/vaporeon",
+		http.StatusOK,
+		`{"name":"vaporeon","evolves_from_species":{"name":"eevee","url":""}}`,
+	)
+
+	return pokesdk.New(pokesdk.Config{
+		Client: &http.Client{Transport: transport},
+	})
+}
+
+func speciesNames(links []*pokesdk.ChainLink) []string {
+	names := []string{}
+	for _, l := range links {
+		names = append(names, l.Species.Name)
+	}
+	return names
+}
+
+func TestEvolutionsOf(t *testing.T) {
+	ctx := context.Background()
+	sdk := evolutionSDK()
+
+	chain, err := sdk.EvolutionsOf(ctx, "eevee")
+	if err != nil {
+		t.Fatalf("failed to get evolutions: %v", err)
+	}
+
+	paths := chain.Chain.Paths()
+	if len(paths) != 3 {
+		t.Fatalf("expected 3 paths, got %d", len(paths))
+	}
+	if names := speciesNames(paths[1]); !reflect.DeepEqual(names, []string{"eevee", "espeon"}) {
+		t.Errorf("unexpected path: %v", names)
+	}
+
+	triggers := []string{}
+	for _, path := range paths {
+		triggers = append(triggers, path[1].EvolutionDetails[0].String())
+	}
+	if !reflect.DeepEqual(triggers, []string{
+		"use-item (item water-stone)",
+		"level-up (min happiness 160, during day)",
+		"level-up (min affection 2, knows fairy move)",
+	}) {
+		t.Errorf("unexpected triggers: %v", triggers)
+	}
+
+	if pre := chain.Chain.PreEvolutionOf("sylveon"); pre == nil || pre.Species.Name != "eevee" {
+		t.Errorf("expected eevee pre-evolution, got %v", pre)
+	}
+	if pre := chain.Chain.PreEvolutionOf("eevee"); pre != nil {
+		t.Errorf("expected no pre-evolution, got %v", pre)
+	}
+	if chain.Chain.Find("pikachu") != nil {
+		t.Errorf("expected pikachu not to be found")
+	}
+}
+
+func TestFinalFormsOf(t *testing.T) {
+	ctx := context.Background()
+	sdk := evolutionSDK()
+
+	forms, err := sdk.FinalFormsOf(ctx, "eevee")
+	if err != nil {
+		t.Fatalf("failed to get final forms: %v", err)
+	}
+	if len(forms) != 3 || forms[2].Name != "sylveon" {
+		t.Errorf("unexpected final forms: %v", forms)
+	}
+
+	forms, err = sdk.FinalFormsOf(ctx, "espeon")
+	if err != nil {
+		t.Fatalf("failed to get final forms: %v", err)
+	}
+	if len(forms) != 1 || forms[0].Name != "espeon" {
+		t.Errorf("expected espeon to be its own final form: %v", forms)
+	}
+}
+
+func TestPreEvolutionOf(t *testing.T) {
+	ctx := context.Background()
+	sdk := evolutionSDK()
+
+	pre, err := sdk.PreEvolutionOf(ctx, "vaporeon")
+	if err != nil {
+		t.Fatalf("failed to get pre-evolution: %v", err)
+	}
+	if pre == nil || pre.Name != "eevee" {
+		t.Errorf("expected eevee, got %v", pre)
+	}
+}
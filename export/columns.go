@@ -0,0 +1,133 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/danielgtaylor/pokesdk"
+)
+
+// Column maps an item to a single output value. Values should be strings,
+// numbers, booleans or `nil` when missing so every format can represent them.
+type Column[T any] struct {
+	Name  string
+	Value func(T) any
+}
+
+// LinkColumns are the columns for list items.
+var LinkColumns = []Column[pokesdk.NamedLink]{
+	{Name: "name", Value: func(l pokesdk.NamedLink) any { return l.Name }},
+	{Name: "url", Value: func(l pokesdk.NamedLink) any { return l.URL }},
+}
+
+// PokemonColumns are the default columns for Pokemon. Types, abilities and
+// stats are flattened into one column each, so the output has a fixed shape.
+var PokemonColumns = []Column[*pokesdk.Pokemon]{
+	{Name: "id", Value: func(p *pokesdk.Pokemon) any { return p.ID }},
+	{Name: "name", Value: func(p *pokesdk.Pokemon) any { return p.Name }},
+	{Name: "species", Value: func(p *pokesdk.Pokemon) any { return p.Species.Name }},
+	{Name: "is_default", Value: func(p *pokesdk.Pokemon) any { return p.IsDefault }},
+	{Name: "height_m", Value: func(p *pokesdk.Pokemon) any { return p.HeightMeters() }},
+	{Name: "weight_kg", Value: func(p *pokesdk.Pokemon) any { return p.WeightKg() }},
+	{Name: "base_experience", Value: func(p *pokesdk.Pokemon) any { return p.BaseExperience }},
+	TypeColumn(1),
+	TypeColumn(2),
+	AbilityColumn(1),
+	AbilityColumn(2),
+	{Name: "hidden_ability", Value: func(p *pokesdk.Pokemon) any {
+		if a, ok := p.HiddenAbility(); ok {
+			return a.Name
+		}
+		return nil
+	}},
+	StatColumn(pokesdk.StatHP),
+	StatColumn(pokesdk.StatAttack),
+	StatColumn(pokesdk.StatDefense),
+	StatColumn(pokesdk.StatSpecialAttack),
+	StatColumn(pokesdk.StatSpecialDefense),
+	StatColumn(pokesdk.StatSpeed),
+	{Name: "stat_total", Value: func(p *pokesdk.Pokemon) any { return p.StatTotal() }},
+}
+
+// TypeColumn returns a column named e.g. `type_1` with the name of the type in
+// the given slot.
+func TypeColumn(slot int) Column[*pokesdk.Pokemon] {
+	return Column[*pokesdk.Pokemon]{
+		Name: fmt.Sprintf("type_%d", slot),
+		Value: func(p *pokesdk.Pokemon) any {
+			for _, t := range p.Types {
+				if t.Slot == slot {
+					return string(t.Type.Name)
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// AbilityColumn returns a column named e.g. `ability_1` with the name of the
+// ability in the given slot, which may be a hidden ability.
+func AbilityColumn(slot int) Column[*pokesdk.Pokemon] {
+	return Column[*pokesdk.Pokemon]{
+		Name: fmt.Sprintf("ability_%d", slot),
+		Value: func(p *pokesdk.Pokemon) any {
+			for _, a := range p.Abilities {
+				if a.Slot == slot {
+					return a.Ability.Name
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// StatColumn returns a column with the base value of the given stat, named
+// after the stat with dashes replaced, e.g. `special_attack`.
+func StatColumn(stat pokesdk.StatName) Column[*pokesdk.Pokemon] {
+	return Column[*pokesdk.Pokemon]{
+		Name: strings.ReplaceAll(string(stat), "-", "_"),
+		Value: func(p *pokesdk.Pokemon) any {
+			for _, s := range p.Stats {
+				if s.Stat.Name == stat {
+					return s.BaseStat
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// Select returns the named columns in the given order, optionally renamed
+// using `name=column`, e.g. `pokemon=name`.
+//
+//	columns, err := export.Select(export.PokemonColumns, "id", "pokemon=name", "hp")
+func Select[T any](columns []Column[T], names ...string) ([]Column[T], error) {
+	byName := make(map[string]Column[T], len(columns))
+	for _, c := range columns {
+		byName[c.Name] = c
+	}
+
+	selected := make([]Column[T], 0, len(names))
+	for _, name := range names {
+		rename, source, ok := strings.Cut(name, "=")
+		if !ok {
+			source = rename
+		}
+		c, ok := byName[source]
+		if !ok {
+			return nil, fmt.Errorf("unknown column %q", source)
+		}
+		c.Name = rename
+		selected = append(selected, c)
+	}
+	return selected, nil
+}
+
+// names returns the names of the columns.
+func names[T any](columns []Column[T]) []string {
+	n := make([]string, len(columns))
+	for i, c := range columns {
+		n[i] = c.Name
+	}
+	return n
+}
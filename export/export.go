@@ -0,0 +1,124 @@
+// Package export streams lists and resources from the API into flat files
+// such as CSV, newline-delimited JSON or Parquet, e.g. for loading into a
+// data warehouse. Rows are written as pages arrive so memory use stays flat no
+// matter how long the list is.
+//
+//	f, err := os.Create("pokemon.csv")
+//	if err != nil {
+//		panic(err)
+//	}
+//	defer f.Close()
+//
+//	n, err := export.Pokemon(ctx, sdk, sdk.ListPokemon(), export.CSV, f, export.PokemonColumns)
+//	if err != nil {
+//		panic(err)
+//	}
+//	fmt.Printf("Exported %d Pokemon\n", n)
+package export
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/danielgtaylor/pokesdk"
+)
+
+// List writes every item from the paginator as a row and returns the number
+// of rows written. Errors reported by the paginator stop the export.
+//
+//	n, err := export.List(ctx, sdk.ListTypes(), export.JSONL, w, export.LinkColumns)
+func List[T any](ctx context.Context, p *pokesdk.Paginator[T], format Format, w io.Writer, columns []Column[T]) (int, error) {
+	out, err := format(w, names(columns))
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	row := make([]any, len(columns))
+	err = each(ctx, p, func(item T) error {
+		if err := writeRow(out, row, columns, item); err != nil {
+			return err
+		}
+		count++
+		return nil
+	})
+	if err != nil {
+		return count, err
+	}
+	return count, out.Close()
+}
+
+// Pokemon hydrates each item from the paginator into a full `Pokemon`,
+// fetching up to `pokesdk.DefaultBatchConcurrency` at a time, and writes them
+// as rows in list order. It returns the number of rows written.
+func Pokemon(ctx context.Context, sdk *pokesdk.SDK, p *pokesdk.Paginator[pokesdk.NamedLink], format Format, w io.Writer, columns []Column[*pokesdk.Pokemon]) (int, error) {
+	out, err := format(w, names(columns))
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	row := make([]any, len(columns))
+	urls := make([]string, 0, max(1, pokesdk.DefaultBatchConcurrency))
+
+	flush := func() error {
+		pokemon, err := pokesdk.FollowBatch[pokesdk.Pokemon](ctx, sdk, urls, pokesdk.BatchFailFast())
+		if err != nil {
+			return err
+		}
+		for _, pkmn := range pokemon {
+			if err := writeRow(out, row, columns, pkmn); err != nil {
+				return err
+			}
+			count++
+		}
+		urls = urls[:0]
+		return nil
+	}
+
+	err = each(ctx, p, func(link pokesdk.NamedLink) error {
+		urls = append(urls, link.URL)
+		if len(urls) == cap(urls) {
+			return flush()
+		}
+		return nil
+	})
+	if err == nil && len(urls) > 0 {
+		err = flush()
+	}
+	if err != nil {
+		return count, err
+	}
+	return count, out.Close()
+}
+
+// each calls `fn` with every item from the paginator in order. It stops at
+// the first error from the paginator, `fn` or the context.
+func each[T any](ctx context.Context, p *pokesdk.Paginator[T], fn func(T) error) error {
+	// Stopping the iterator on every return releases the paginator, which
+	// would otherwise block sending the next result.
+	iter, stop := p.AllWithCancel(ctx)
+	defer stop()
+
+	for result := range iter {
+		if result.Error != nil {
+			return result.Error
+		}
+		if err := fn(result.Value); err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+// writeRow fills the row from the item and writes it.
+func writeRow[T any](out Writer, row []any, columns []Column[T], item T) error {
+	for i, c := range columns {
+		row[i] = c.Value(item)
+	}
+	if err := out.Write(row); err != nil {
+		return fmt.Errorf("failed to write row: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+package export_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/pokesdk"
+	"github.com/danielgtaylor/pokesdk/export"
+)
+
+const bulbasaur = `{
+	"id": 1,
+	"name": "bulbasaur",
+	"height": 7,
+	"weight": 69,
+	"is_default": true,
+	"species": {"name": "bulbasaur", "url": ""},
+	"types": [
+		{"slot": 1, "type": {"name": "grass", "url": ""}},
+		{"slot": 2, "type": {"name": "poison", "url": ""}}
+	],
+	"abilities": [
+		{"slot": 1, "is_hidden": false, "ability": {"name": "overgrow", "url": ""}},
+		{"slot": 3, "is_hidden": true, "ability": {"name": "chlorophyll", "url": ""}}
+	],
+	"stats": [
+		{"base_stat": 45, "stat": {"name": "hp", "url": ""}},
+		{"base_stat": 49, "stat": {"name": "attack", "url": ""}}
+	]
+}`
+
+const charmander = `{
+	"id": 4,
+	"name": "charmander",
+	"types": [{"slot": 1, "type": {"name": "fire", "url": ""}}],
+	"stats": [{"base_stat": 39, "stat": {"name": "hp", "url": ""}}]
+}`
+
+func fakeServer(t *testing.T) *httptest.Server {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.String() {
+		case "/api/v2/pokemon":
+			fmt.Fprintf(w, `{"count": 2, "next": "%s/api/v2/pokemon?offset=1&limit=1", "results": [{"name": "bulbasaur", "url": "%s/api/v2/pokemon/1/"}]}`, server.URL, server.URL)
+		case "/api/v2/pokemon?offset=1&limit=1":
+			fmt.Fprintf(w, `{"count": 2, "next": null, "results": [{"name": "charmander", "url": "%s/api/v2/pokemon/4/"}]}`, server.URL)
+		case "/api/v2/pokemon/1/":
+			w.Write([]byte(bulbasaur))
+		case "/api/v2/pokemon/4/":
+			w.Write([]byte(charmander))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestList(t *testing.T) {
+	ctx := context.Background()
+	server := fakeServer(t)
+	sdk := pokesdk.New(pokesdk.Config{BaseURL: server.URL})
+
+	buf := &bytes.Buffer{}
+	n, err := export.List(ctx, sdk.ListPokemon(), export.CSV, buf, export.LinkColumns)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if n != 2 {
+		t.Errorf("expected 2 rows, got %d", n)
+	}
+
+	expected := "name,url\n" +
+		"bulbasaur," + server.URL + "/api/v2/pokemon/1/\n" +
+		"charmander," + server.URL + "/api/v2/pokemon/4/\n"
+	if buf.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+}
+
+func TestPokemonCSV(t *testing.T) {
+	ctx := context.Background()
+	server := fakeServer(t)
+	sdk := pokesdk.New(pokesdk.Config{BaseURL: server.URL})
+
+	columns, err := export.Select(export.PokemonColumns, "id", "pokemon=name", "type_1", "type_2", "ability_1", "hidden_ability", "hp", "height_m")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	buf := &bytes.Buffer{}
+	if _, err := export.Pokemon(ctx, sdk, sdk.ListPokemon(), export.CSV, buf, columns); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := strings.Join([]string{
+		"id,pokemon,type_1,type_2,ability_1,hidden_ability,hp,height_m",
+		"1,bulbasaur,grass,poison,overgrow,chlorophyll,45,0.7",
+		"4,charmander,fire,,,,39,0",
+		"",
+	}, "\n")
+	if buf.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+}
+
+func TestPokemonJSONL(t *testing.T) {
+	ctx := context.Background()
+	server := fakeServer(t)
+	sdk := pokesdk.New(pokesdk.Config{BaseURL: server.URL})
+
+	columns, err := export.Select(export.PokemonColumns, "name", "type_2", "attack", "is_default")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	buf := &bytes.Buffer{}
+	if _, err := export.Pokemon(ctx, sdk, sdk.ListPokemon(), export.JSONL, buf, columns); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `{"name":"bulbasaur","type_2":"poison","attack":49,"is_default":true}` + "\n" +
+		`{"name":"charmander","type_2":null,"attack":null,"is_default":false}` + "\n"
+	if buf.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+}
+
+func TestSelectUnknown(t *testing.T) {
+	if _, err := export.Select(export.PokemonColumns, "nope"); err == nil {
+		t.Error("expected error for unknown column")
+	}
+}
+
+func TestListError(t *testing.T) {
+	ctx := context.Background()
+	server := fakeServer(t)
+	sdk := pokesdk.New(pokesdk.Config{BaseURL: server.URL})
+
+	_, err := export.List(ctx, sdk.ListTypes(), export.CSV, &bytes.Buffer{}, export.LinkColumns)
+	if err == nil {
+		t.Error("expected error")
+	}
+}
+
+// endlessTransport serves an endless list with one item per page.
+type endlessTransport struct{}
+
+func (endlessTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	offset, _ := strconv.Atoi(req.URL.Query().Get("offset"))
+	body := fmt.Sprintf(`{"count": 1000000, "next": "https://pokeapi.co/api/v2/pokemon?offset=%d&limit=1", "results": [{"name": "p%d", "url": "https://pokeapi.co/api/v2/pokemon/%d/"}]}`, offset+1, offset, offset)
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}, nil
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]any) error { return errors.New("disk full") }
+func (failingWriter) Close() error      { return nil }
+
+func TestListWriteErrorStopsPaginator(t *testing.T) {
+	sdk := pokesdk.New(pokesdk.Config{Client: &http.Client{Transport: endlessTransport{}}})
+	failing := func(io.Writer, []string) (export.Writer, error) {
+		return failingWriter{}, nil
+	}
+
+	before := runtime.NumGoroutine()
+	if _, err := export.List(context.Background(), sdk.ListPokemon(), failing, io.Discard, export.LinkColumns); err == nil {
+		t.Fatal("expected write error")
+	}
+
+	// The paginator's goroutine must exit rather than block on the channel.
+	deadline := time.Now().Add(2 * time.Second)
+	for runtime.NumGoroutine() > before {
+		if time.Now().After(deadline) {
+			t.Fatalf("paginator goroutine leaked: %d > %d", runtime.NumGoroutine(), before)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
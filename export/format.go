@@ -0,0 +1,127 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// Writer writes rows in some format. Rows hold one value per column, with
+// `nil` for missing values.
+type Writer interface {
+	Write(row []any) error
+
+	// Close flushes any buffered output. It does not close the underlying
+	// `io.Writer`.
+	Close() error
+}
+
+// Format creates a writer for the given columns.
+type Format func(w io.Writer, columns []string) (Writer, error)
+
+// Formats are the available formats by name, used by the CLI. Applications
+// can register their own, e.g. to write Avro using a third-party library.
+var Formats = map[string]Format{
+	"csv":     CSV,
+	"jsonl":   JSONL,
+	"parquet": Parquet,
+}
+
+// FormatNames returns the sorted names of the registered formats.
+func FormatNames() []string {
+	names := make([]string, 0, len(Formats))
+	for name := range Formats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// CSV writes a header row and then one line per row. Missing values are
+// written as empty strings.
+func CSV(w io.Writer, columns []string) (Writer, error) {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(columns); err != nil {
+		return nil, fmt.Errorf("failed to write header: %w", err)
+	}
+	return &csvWriter{w: cw, record: make([]string, len(columns))}, nil
+}
+
+type csvWriter struct {
+	w      *csv.Writer
+	record []string
+}
+
+func (c *csvWriter) Write(row []any) error {
+	for i, v := range row {
+		c.record[i] = formatValue(v)
+	}
+	return c.w.Write(c.record)
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// formatValue formats a single value for text output.
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case fmt.Stringer:
+		return v.String()
+	}
+	return fmt.Sprint(v)
+}
+
+// JSONL writes one JSON object per line, with keys in column order.
+func JSONL(w io.Writer, columns []string) (Writer, error) {
+	keys := make([][]byte, len(columns))
+	for i, c := range columns {
+		b, err := json.Marshal(c)
+		if err != nil {
+			return nil, err
+		}
+		keys[i] = b
+	}
+	return &jsonlWriter{w: w, keys: keys}, nil
+}
+
+type jsonlWriter struct {
+	w    io.Writer
+	keys [][]byte
+	buf  []byte
+}
+
+func (j *jsonlWriter) Write(row []any) error {
+	j.buf = append(j.buf[:0], '{')
+	for i, v := range row {
+		if i > 0 {
+			j.buf = append(j.buf, ',')
+		}
+		value, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s: %w", j.keys[i], err)
+		}
+		j.buf = append(j.buf, j.keys[i]...)
+		j.buf = append(j.buf, ':')
+		j.buf = append(j.buf, value...)
+	}
+	j.buf = append(j.buf, '}', '\n')
+	_, err := j.w.Write(j.buf)
+	return err
+}
+
+func (j *jsonlWriter) Close() error {
+	return nil
+}
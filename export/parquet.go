@@ -0,0 +1,433 @@
+package export
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+// ParquetRowGroupSize is how many rows the Parquet writer buffers before
+// writing them out as a row group. Larger groups compress better but use
+// more memory.
+var ParquetRowGroupSize = 10000
+
+// Parquet physical types, encodings and other constants from the Parquet
+// format's Thrift definitions.
+const (
+	parquetBoolean   = 0
+	parquetInt64     = 2
+	parquetDouble    = 5
+	parquetByteArray = 6
+
+	parquetOptional  = 1
+	parquetUTF8      = 0
+	parquetPlain     = 0
+	parquetRLE       = 3
+	parquetGzip      = 2
+	parquetDataPage  = 0
+	parquetCreatedBy = "pokesdk export"
+)
+
+var parquetMagic = []byte("PAR1")
+
+// Parquet writes a Parquet file with one optional column per export column,
+// compressed with gzip. Column types are inferred from the values in the
+// first row group: booleans, integers, floats (which win over integers) or
+// strings, which are also used for columns with mixed or only missing values.
+// Rows are buffered in groups of `ParquetRowGroupSize`.
+func Parquet(w io.Writer, columns []string) (Writer, error) {
+	if _, err := w.Write(parquetMagic); err != nil {
+		return nil, err
+	}
+	return &parquetWriter{w: w, offset: int64(len(parquetMagic)), columns: columns}, nil
+}
+
+type parquetWriter struct {
+	w       io.Writer
+	offset  int64
+	columns []string
+	types   []int32
+	rows    [][]any
+	groups  []parquetRowGroup
+	total   int64
+}
+
+type parquetRowGroup struct {
+	rows   int64
+	size   int64
+	chunks []parquetChunk
+}
+
+type parquetChunk struct {
+	offset           int64
+	values           int64
+	uncompressedSize int64
+	compressedSize   int64
+}
+
+func (p *parquetWriter) Write(row []any) error {
+	p.rows = append(p.rows, append([]any(nil), row...))
+	if len(p.rows) >= max(1, ParquetRowGroupSize) {
+		return p.flush()
+	}
+	return nil
+}
+
+func (p *parquetWriter) Close() error {
+	if err := p.flush(); err != nil {
+		return err
+	}
+	if p.types == nil {
+		p.inferTypes()
+	}
+
+	footer := p.footer()
+	footer = binary.LittleEndian.AppendUint32(footer, uint32(len(footer)))
+	footer = append(footer, parquetMagic...)
+	_, err := p.w.Write(footer)
+	return err
+}
+
+// inferTypes picks each column's type from the buffered rows.
+func (p *parquetWriter) inferTypes() {
+	p.types = make([]int32, len(p.columns))
+	for i := range p.columns {
+		var hasBool, hasInt, hasFloat, hasOther bool
+		for _, row := range p.rows {
+			switch row[i].(type) {
+			case nil:
+			case bool:
+				hasBool = true
+			case int, int8, int16, int32, int64, uint8, uint16, uint32:
+				hasInt = true
+			case float32, float64:
+				hasFloat = true
+			default:
+				hasOther = true
+			}
+		}
+
+		switch {
+		case hasOther || (hasBool && (hasInt || hasFloat)):
+			p.types[i] = parquetByteArray
+		case hasBool:
+			p.types[i] = parquetBoolean
+		case hasFloat:
+			p.types[i] = parquetDouble
+		case hasInt:
+			p.types[i] = parquetInt64
+		default:
+			p.types[i] = parquetByteArray
+		}
+	}
+}
+
+// flush writes the buffered rows as a row group with one data page per
+// column.
+func (p *parquetWriter) flush() error {
+	if len(p.rows) == 0 {
+		return nil
+	}
+	if p.types == nil {
+		p.inferTypes()
+	}
+
+	group := parquetRowGroup{rows: int64(len(p.rows))}
+	for i, name := range p.columns {
+		page, err := p.page(i)
+		if err != nil {
+			return fmt.Errorf("column %s: %w", name, err)
+		}
+
+		compressed := &bytes.Buffer{}
+		gz := gzip.NewWriter(compressed)
+		if _, err := gz.Write(page); err != nil {
+			return err
+		}
+		if err := gz.Close(); err != nil {
+			return err
+		}
+
+		t := &thrift{}
+		t.i32(1, parquetDataPage)
+		t.i32(2, int32(len(page)))
+		t.i32(3, int32(compressed.Len()))
+		t.beginStruct(5)
+		t.i32(1, int32(len(p.rows)))
+		t.i32(2, parquetPlain)
+		t.i32(3, parquetRLE)
+		t.i32(4, parquetRLE)
+		t.endStruct()
+		t.endStruct()
+
+		chunk := parquetChunk{
+			offset:           p.offset,
+			values:           int64(len(p.rows)),
+			uncompressedSize: int64(len(t.buf) + len(page)),
+			compressedSize:   int64(len(t.buf) + compressed.Len()),
+		}
+		if err := p.write(t.buf, compressed.Bytes()); err != nil {
+			return err
+		}
+		group.size += chunk.uncompressedSize
+		group.chunks = append(group.chunks, chunk)
+	}
+
+	p.groups = append(p.groups, group)
+	p.total += group.rows
+	p.rows = p.rows[:0]
+	return nil
+}
+
+func (p *parquetWriter) write(chunks ...[]byte) error {
+	for _, b := range chunks {
+		n, err := p.w.Write(b)
+		p.offset += int64(n)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// page encodes the buffered values of a column as an uncompressed data page:
+// definition levels marking missing values, then the present values.
+func (p *parquetWriter) page(col int) ([]byte, error) {
+	levels := []byte{}
+	values := []byte{}
+	bits, nbits := byte(0), 0
+
+	for start := 0; start < len(p.rows); {
+		// Definition levels use the RLE hybrid encoding with a bit width of
+		// one, written here as runs of equal levels.
+		defined := p.rows[start][col] != nil
+		end := start
+		for end < len(p.rows) && (p.rows[end][col] != nil) == defined {
+			end++
+		}
+		levels = binary.AppendUvarint(levels, uint64(end-start)<<1)
+		if defined {
+			levels = append(levels, 1)
+		} else {
+			levels = append(levels, 0)
+		}
+		start = end
+	}
+
+	for _, row := range p.rows {
+		v := row[col]
+		if v == nil {
+			continue
+		}
+		switch p.types[col] {
+		case parquetBoolean:
+			b, ok := v.(bool)
+			if !ok {
+				return nil, fmt.Errorf("cannot write %v as a boolean", v)
+			}
+			if b {
+				bits |= 1 << nbits
+			}
+			if nbits++; nbits == 8 {
+				values = append(values, bits)
+				bits, nbits = 0, 0
+			}
+		case parquetInt64:
+			n, ok := toInt64(v)
+			if !ok {
+				return nil, fmt.Errorf("cannot write %v as an integer", v)
+			}
+			values = binary.LittleEndian.AppendUint64(values, uint64(n))
+		case parquetDouble:
+			f, ok := toFloat64(v)
+			if !ok {
+				return nil, fmt.Errorf("cannot write %v as a float", v)
+			}
+			values = binary.LittleEndian.AppendUint64(values, math.Float64bits(f))
+		default:
+			s := formatValue(v)
+			values = binary.LittleEndian.AppendUint32(values, uint32(len(s)))
+			values = append(values, s...)
+		}
+	}
+	if nbits > 0 {
+		values = append(values, bits)
+	}
+
+	page := binary.LittleEndian.AppendUint32(nil, uint32(len(levels)))
+	page = append(page, levels...)
+	return append(page, values...), nil
+}
+
+func toInt64(v any) (int64, bool) {
+	switch v := v.(type) {
+	case int:
+		return int64(v), true
+	case int8:
+		return int64(v), true
+	case int16:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	case uint8:
+		return int64(v), true
+	case uint16:
+		return int64(v), true
+	case uint32:
+		return int64(v), true
+	case float64:
+		return int64(v), v == math.Trunc(v)
+	}
+	return 0, false
+}
+
+func toFloat64(v any) (float64, bool) {
+	switch v := v.(type) {
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	n, ok := toInt64(v)
+	return float64(n), ok
+}
+
+// footer encodes the file metadata.
+func (p *parquetWriter) footer() []byte {
+	t := &thrift{}
+	t.i32(1, 1)
+
+	t.beginList(2, thriftStruct, len(p.columns)+1)
+	t.beginElem()
+	t.string(4, "schema")
+	t.i32(5, int32(len(p.columns)))
+	t.endStruct()
+	for i, name := range p.columns {
+		t.beginElem()
+		t.i32(1, p.types[i])
+		t.i32(3, parquetOptional)
+		t.string(4, name)
+		if p.types[i] == parquetByteArray {
+			t.i32(6, parquetUTF8)
+		}
+		t.endStruct()
+	}
+
+	t.i64(3, p.total)
+
+	t.beginList(4, thriftStruct, len(p.groups))
+	for _, g := range p.groups {
+		t.beginElem()
+		t.beginList(1, thriftStruct, len(g.chunks))
+		for i, c := range g.chunks {
+			t.beginElem()
+			t.i64(2, c.offset)
+			t.beginStruct(3)
+			t.i32(1, p.types[i])
+			t.beginList(2, thriftI32, 2)
+			t.elemI32(parquetPlain)
+			t.elemI32(parquetRLE)
+			t.beginList(3, thriftBinary, 1)
+			t.elemString(p.columns[i])
+			t.i32(4, parquetGzip)
+			t.i64(5, c.values)
+			t.i64(6, c.uncompressedSize)
+			t.i64(7, c.compressedSize)
+			t.i64(9, c.offset)
+			t.endStruct()
+			t.endStruct()
+		}
+		t.i64(2, g.size)
+		t.i64(3, g.rows)
+		t.endStruct()
+	}
+
+	t.string(6, parquetCreatedBy)
+	t.endStruct()
+	return t.buf
+}
+
+// Thrift compact protocol types.
+const (
+	thriftI32    = 5
+	thriftI64    = 6
+	thriftBinary = 8
+	thriftList   = 9
+	thriftStruct = 12
+)
+
+// thrift is a minimal Thrift compact protocol encoder for Parquet metadata.
+// Fields must be written in increasing order within each struct.
+type thrift struct {
+	buf  []byte
+	last []int16
+	id   int16
+}
+
+func (t *thrift) field(id int16, typ byte) {
+	if delta := id - t.id; delta > 0 && delta <= 15 {
+		t.buf = append(t.buf, byte(delta)<<4|typ)
+	} else {
+		t.buf = append(t.buf, typ)
+		t.buf = binary.AppendVarint(t.buf, int64(id))
+	}
+	t.id = id
+}
+
+func (t *thrift) i32(id int16, v int32) {
+	t.field(id, thriftI32)
+	t.buf = binary.AppendVarint(t.buf, int64(v))
+}
+
+func (t *thrift) i64(id int16, v int64) {
+	t.field(id, thriftI64)
+	t.buf = binary.AppendVarint(t.buf, v)
+}
+
+func (t *thrift) string(id int16, v string) {
+	t.field(id, thriftBinary)
+	t.elemString(v)
+}
+
+func (t *thrift) beginStruct(id int16) {
+	t.field(id, thriftStruct)
+	t.beginElem()
+}
+
+// beginElem starts a struct inside a list.
+func (t *thrift) beginElem() {
+	t.last = append(t.last, t.id)
+	t.id = 0
+}
+
+func (t *thrift) endStruct() {
+	t.buf = append(t.buf, 0)
+	if n := len(t.last); n > 0 {
+		t.id = t.last[n-1]
+		t.last = t.last[:n-1]
+	}
+}
+
+func (t *thrift) beginList(id int16, elem byte, size int) {
+	t.field(id, thriftList)
+	if size < 15 {
+		t.buf = append(t.buf, byte(size)<<4|elem)
+	} else {
+		t.buf = append(t.buf, 0xf0|elem)
+		t.buf = binary.AppendUvarint(t.buf, uint64(size))
+	}
+}
+
+func (t *thrift) elemI32(v int32) {
+	t.buf = binary.AppendVarint(t.buf, int64(v))
+}
+
+func (t *thrift) elemString(v string) {
+	t.buf = binary.AppendUvarint(t.buf, uint64(len(v)))
+	t.buf = append(t.buf, v...)
+}
@@ -0,0 +1,327 @@
+package export_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/danielgtaylor/pokesdk"
+	"github.com/danielgtaylor/pokesdk/export"
+)
+
+// thriftReader decodes the Thrift compact protocol into generic values:
+// integers as int64, binaries as strings, lists as []any and structs as maps
+// from field IDs.
+type thriftReader struct {
+	data []byte
+	pos  int
+	err  error
+}
+
+func (r *thriftReader) byte() byte {
+	if r.pos >= len(r.data) {
+		r.err = io.ErrUnexpectedEOF
+		return 0
+	}
+	r.pos++
+	return r.data[r.pos-1]
+}
+
+func (r *thriftReader) uvarint() uint64 {
+	v, n := binary.Uvarint(r.data[min(r.pos, len(r.data)):])
+	if n <= 0 {
+		r.err = io.ErrUnexpectedEOF
+		return 0
+	}
+	r.pos += n
+	return v
+}
+
+func (r *thriftReader) varint() int64 {
+	v := r.uvarint()
+	return int64(v>>1) ^ -int64(v&1)
+}
+
+func (r *thriftReader) value(typ byte) any {
+	switch typ {
+	case 1, 2:
+		return typ == 1
+	case 3:
+		return int64(int8(r.byte()))
+	case 4, 5, 6:
+		return r.varint()
+	case 8:
+		n := int(r.uvarint())
+		if r.err != nil || r.pos+n > len(r.data) {
+			r.err = io.ErrUnexpectedEOF
+			return ""
+		}
+		r.pos += n
+		return string(r.data[r.pos-n : r.pos])
+	case 9:
+		header := r.byte()
+		size := int(header >> 4)
+		if size == 15 {
+			size = int(r.uvarint())
+		}
+		list := []any{}
+		for i := 0; i < size && r.err == nil; i++ {
+			list = append(list, r.value(header&0x0f))
+		}
+		return list
+	case 12:
+		fields := map[int16]any{}
+		id := int16(0)
+		for r.err == nil {
+			header := r.byte()
+			if header == 0 {
+				break
+			}
+			if delta := int16(header >> 4); delta > 0 {
+				id += delta
+			} else {
+				id = int16(r.varint())
+			}
+			fields[id] = r.value(header & 0x0f)
+		}
+		return fields
+	}
+	r.err = fmt.Errorf("unsupported Thrift type %d", typ)
+	return nil
+}
+
+// readStruct decodes a Thrift struct starting at `offset` and returns it along
+// with its encoded length.
+func readStruct(t *testing.T, data []byte, offset int64) (map[int16]any, int64) {
+	t.Helper()
+	r := &thriftReader{data: data, pos: int(offset)}
+	v := r.value(12)
+	if r.err != nil {
+		t.Fatalf("invalid Thrift struct at %d: %v", offset, r.err)
+	}
+	return v.(map[int16]any), int64(r.pos) - offset
+}
+
+type parquetColumn struct {
+	Name  string
+	Type  int64
+	Rows  []int64
+	Pages [][]byte
+}
+
+// readParquet decodes the footer of a Parquet file and checks that the
+// metadata matches the pages it points to. It returns the total row count
+// and the columns with their uncompressed data pages, one per row group.
+func readParquet(t *testing.T, data []byte) (int64, []parquetColumn) {
+	t.Helper()
+	if !bytes.HasPrefix(data, []byte("PAR1")) || !bytes.HasSuffix(data, []byte("PAR1")) {
+		t.Fatalf("missing Parquet magic")
+	}
+
+	size := int64(binary.LittleEndian.Uint32(data[len(data)-8:]))
+	footerStart := int64(len(data)) - 8 - size
+	footer, n := readStruct(t, data, footerStart)
+	if n != size {
+		t.Fatalf("footer is %d bytes, expected %d", n, size)
+	}
+	if footer[1] != int64(1) {
+		t.Errorf("expected version 1, got %v", footer[1])
+	}
+
+	schema := footer[2].([]any)
+	root := schema[0].(map[int16]any)
+	if root[4] != "schema" || root[5] != int64(len(schema)-1) {
+		t.Errorf("unexpected schema root %v", root)
+	}
+	columns := []parquetColumn{}
+	for _, e := range schema[1:] {
+		e := e.(map[int16]any)
+		// Every column is optional, and strings are annotated as UTF-8.
+		if e[3] != int64(1) {
+			t.Errorf("expected optional column, got %v", e)
+		}
+		if (e[1] == int64(6)) != (e[6] == int64(0)) {
+			t.Errorf("expected UTF-8 annotation only for byte arrays, got %v", e)
+		}
+		columns = append(columns, parquetColumn{Name: e[4].(string), Type: e[1].(int64)})
+	}
+
+	numRows := footer[3].(int64)
+	rows := int64(0)
+	offset := int64(4)
+	for _, g := range footer[4].([]any) {
+		g := g.(map[int16]any)
+		groupRows := g[3].(int64)
+		rows += groupRows
+
+		chunks := g[1].([]any)
+		if len(chunks) != len(columns) {
+			t.Fatalf("expected %d column chunks, got %d", len(columns), len(chunks))
+		}
+		groupSize := int64(0)
+		for i, c := range chunks {
+			c := c.(map[int16]any)
+			meta := c[3].(map[int16]any)
+
+			// Chunks follow each other without gaps, each starting with its
+			// data page.
+			if c[2] != offset || meta[9] != offset {
+				t.Errorf("%s: expected chunk and data page at %d, got %v and %v", columns[i].Name, offset, c[2], meta[9])
+			}
+			if meta[1] != columns[i].Type || !reflect.DeepEqual(meta[3], []any{columns[i].Name}) {
+				t.Errorf("%s: unexpected column metadata %v", columns[i].Name, meta)
+			}
+			if meta[4] != int64(2) || meta[5] != groupRows {
+				t.Errorf("%s: expected gzip and %d values, got %v", columns[i].Name, groupRows, meta)
+			}
+
+			header, headerSize := readStruct(t, data, offset)
+			dataHeader := header[5].(map[int16]any)
+			if header[1] != int64(0) || dataHeader[1] != groupRows {
+				t.Errorf("%s: expected a data page with %d values, got %v", columns[i].Name, groupRows, header)
+			}
+			uncompressed, compressed := header[2].(int64), header[3].(int64)
+			if meta[6] != headerSize+uncompressed || meta[7] != headerSize+compressed {
+				t.Errorf("%s: sizes %v and %v do not match the page at %d", columns[i].Name, meta[6], meta[7], offset)
+			}
+
+			start := offset + headerSize
+			zr, err := gzip.NewReader(bytes.NewReader(data[start : start+compressed]))
+			if err != nil {
+				t.Fatalf("%s: %v", columns[i].Name, err)
+			}
+			page, err := io.ReadAll(zr)
+			if err != nil || int64(len(page)) != uncompressed {
+				t.Fatalf("%s: expected %d byte page, got %d (%v)", columns[i].Name, uncompressed, len(page), err)
+			}
+			columns[i].Pages = append(columns[i].Pages, page)
+			columns[i].Rows = append(columns[i].Rows, groupRows)
+
+			offset = start + compressed
+			groupSize += meta[6].(int64)
+		}
+		if g[2] != groupSize {
+			t.Errorf("expected row group size %d, got %v", groupSize, g[2])
+		}
+	}
+
+	if offset != footerStart {
+		t.Errorf("expected footer at %d, got %d", offset, footerStart)
+	}
+	if rows != numRows {
+		t.Errorf("expected %d rows in row groups, got %d", numRows, rows)
+	}
+	return numRows, columns
+}
+
+func TestPokemonParquet(t *testing.T) {
+	ctx := context.Background()
+	server := fakeServer(t)
+	sdk := pokesdk.New(pokesdk.Config{BaseURL: server.URL})
+
+	columns, err := export.Select(export.PokemonColumns, "id", "name", "type_2", "height_m", "is_default")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	buf := &bytes.Buffer{}
+	n, err := export.Pokemon(ctx, sdk, sdk.ListPokemon(), export.Formats["parquet"], buf, columns)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rows, cols := readParquet(t, buf.Bytes())
+	if rows != int64(n) || rows != 2 {
+		t.Errorf("expected 2 rows, got %d", rows)
+	}
+
+	schema := []string{}
+	for _, c := range cols {
+		schema = append(schema, fmt.Sprintf("%s:%d", c.Name, c.Type))
+	}
+	expected := []string{"id:2", "name:6", "type_2:6", "height_m:5", "is_default:0"}
+	if !reflect.DeepEqual(schema, expected) {
+		t.Errorf("expected schema %v, got %v", expected, schema)
+	}
+
+	// Names are written as length-prefixed strings after the definition
+	// levels.
+	if !bytes.HasSuffix(cols[1].Pages[0], []byte("\x09\x00\x00\x00bulbasaur\x0a\x00\x00\x00charmander")) {
+		t.Errorf("unexpected name page: %q", cols[1].Pages[0])
+	}
+
+	// Integers are written as 64-bit little-endian values.
+	ids := cols[0].Pages[0][len(cols[0].Pages[0])-16:]
+	if binary.LittleEndian.Uint64(ids) != 1 || binary.LittleEndian.Uint64(ids[8:]) != 4 {
+		t.Errorf("unexpected id page: %v", cols[0].Pages[0])
+	}
+}
+
+func TestParquetRowGroups(t *testing.T) {
+	size := export.ParquetRowGroupSize
+	export.ParquetRowGroupSize = 2
+	defer func() { export.ParquetRowGroupSize = size }()
+
+	buf := &bytes.Buffer{}
+	w, err := export.Parquet(buf, []string{"hp", "legendary", "weight", "name"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, row := range [][]any{
+		{45, false, 6.9, "bulbasaur"},
+		{nil, nil, nil, nil},
+		{106, true, 122.0, "mewtwo"},
+		{100, true, nil, "mew"},
+		{39, false, 8.5, nil},
+	} {
+		if err := w.Write(row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, cols := readParquet(t, buf.Bytes())
+	if rows != 5 {
+		t.Errorf("expected 5 rows, got %d", rows)
+	}
+	for _, c := range cols {
+		if !reflect.DeepEqual(c.Rows, []int64{2, 2, 1}) {
+			t.Errorf("%s: expected row groups of 2, 2 and 1 rows, got %v", c.Name, c.Rows)
+		}
+	}
+
+	// The second row group holds mewtwo and mew, with the definition levels
+	// as a single run of two present values.
+	if !bytes.Equal(cols[0].Pages[1][:6], []byte{2, 0, 0, 0, 4, 1}) {
+		t.Errorf("unexpected definition levels: %v", cols[0].Pages[1])
+	}
+	if hp := cols[0].Pages[1][6:]; binary.LittleEndian.Uint64(hp) != 106 || binary.LittleEndian.Uint64(hp[8:]) != 100 {
+		t.Errorf("unexpected hp page: %v", cols[0].Pages[1])
+	}
+}
+
+func TestParquetTypeMismatch(t *testing.T) {
+	size := export.ParquetRowGroupSize
+	export.ParquetRowGroupSize = 1
+	defer func() { export.ParquetRowGroupSize = size }()
+
+	w, err := export.Parquet(io.Discard, []string{"hp"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The column is an integer column after the first row group.
+	if err := w.Write([]any{45}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Write([]any{"lots"}); err == nil {
+		t.Error("expected type mismatch error")
+	}
+}
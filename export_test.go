@@ -0,0 +1,11 @@
+package pokesdk
+
+// UnmarshalFast exposes the `pokesdk_fastjson` decoder to tests in every
+// build.
+var UnmarshalFast = unmarshalFast
+
+// WaitingRequests returns the number of requests waiting for
+// `Config.MaxConcurrentRequests`.
+func WaitingRequests(s *SDK) int {
+	return s.scheduler.waitingCount()
+}
@@ -0,0 +1,245 @@
+package pokesdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+var extraType = reflect.TypeOf(map[string]json.RawMessage{})
+
+// fillExtra stores any object keys in `data` which the structs in `v` do not
+// model in their `Extra` fields, at any depth. `v` must already hold the value
+// decoded from `data`. The unknown values are kept as the exact bytes from the
+// response, which is parsed once however deeply the models are nested. Bodies
+// which are not JSON, e.g. from a MessagePack codec, are decoded with `codec`
+// and converted to JSON first.
+func fillExtra(codec Codec, data []byte, v any) error {
+	root, err := parseRaw(data)
+	if err != nil {
+		var tree any
+		if err := codec.Unmarshal(data, &tree); err != nil {
+			return err
+		}
+		if data, err = marshalNoEscape(tree); err != nil {
+			return err
+		}
+		if root, err = parseRaw(data); err != nil {
+			return err
+		}
+	}
+	walkExtra(reflect.ValueOf(v), root)
+	return nil
+}
+
+func walkExtra(v reflect.Value, node *rawNode) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if !v.IsNil() {
+			walkExtra(v.Elem(), node)
+		}
+	case reflect.Struct:
+		if node.members == nil {
+			return
+		}
+
+		fields := map[string]reflect.Value{}
+		structFields(v, fields)
+		unknown := map[string]json.RawMessage{}
+		for _, m := range node.members {
+			if f, ok := fields[m.key]; ok {
+				walkExtra(f, m.value)
+			} else {
+				// The data may be reused by the caller once decoding is done.
+				unknown[m.key] = append(json.RawMessage(nil), m.value.raw...)
+			}
+		}
+
+		extra := v.FieldByName("Extra")
+		if len(unknown) > 0 && extra.IsValid() && extra.Type() == extraType && extra.CanSet() {
+			extra.Set(reflect.ValueOf(unknown))
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len() && i < len(node.items); i++ {
+			walkExtra(v.Index(i), node.items[i])
+		}
+	case reflect.Map:
+		if node.members == nil || v.Type().Key().Kind() != reflect.String || v.Type().Elem().Kind() != reflect.Struct {
+			return
+		}
+		// Map values are not addressable, so fill a copy and store it.
+		for _, m := range node.members {
+			key := reflect.ValueOf(m.key).Convert(v.Type().Key())
+			value := v.MapIndex(key)
+			if !value.IsValid() {
+				continue
+			}
+			elem := reflect.New(value.Type()).Elem()
+			elem.Set(value)
+			walkExtra(elem, m.value)
+			v.SetMapIndex(key, elem)
+		}
+	}
+}
+
+// structFields maps the JSON keys of a struct value to its fields, including
+// those of embedded structs. See `schemaFields`.
+func structFields(v reflect.Value, fields map[string]reflect.Value) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" || !f.IsExported() {
+			continue
+		}
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			structFields(v.Field(i), fields)
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = v.Field(i)
+	}
+}
+
+// marshalWithExtra encodes `v`, which must be a struct type without a custom
+// `MarshalJSON`, and adds the keys from `extra` to the resulting object.
+func marshalWithExtra(v any, extra map[string]json.RawMessage) ([]byte, error) {
+	b, err := marshalNoEscape(v)
+	if err != nil || len(extra) == 0 {
+		return b, err
+	}
+
+	var merged map[string]json.RawMessage
+	if err := json.Unmarshal(b, &merged); err != nil {
+		return nil, err
+	}
+	for k, r := range extra {
+		merged[k] = r
+	}
+	return marshalObject(merged)
+}
+
+// marshalNoEscape encodes `v` like `json.Marshal`, but without escaping HTML
+// characters, so that raw values in `Extra` fields are written back as they
+// were received. Whether to escape is left to the caller's own encoder.
+func marshalNoEscape(v any) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// marshalObject encodes an object from raw values in sorted key order, like
+// `json.Marshal` does for maps, but without rewriting the values.
+func marshalObject(fields map[string]json.RawMessage) ([]byte, error) {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	b := []byte{'{'}
+	for i, k := range keys {
+		r := fields[k]
+		if len(r) == 0 {
+			r = json.RawMessage("null")
+		} else if !json.Valid(r) {
+			return nil, fmt.Errorf("invalid JSON for field %q", k)
+		}
+
+		key, err := marshalNoEscape(k)
+		if err != nil {
+			return nil, err
+		}
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = append(b, key...)
+		b = append(b, ':')
+		b = append(b, r...)
+	}
+	return append(b, '}'), nil
+}
+
+// rawNode is a JSON value along with its object members or array items, which
+// refer to the original bytes.
+type rawNode struct {
+	raw     []byte
+	members []rawMember
+	items   []*rawNode
+}
+
+type rawMember struct {
+	key   string
+	value *rawNode
+}
+
+// parseRaw splits a JSON document into nodes in a single pass.
+func parseRaw(data []byte) (*rawNode, error) {
+	s := &jsonScanner{data: data}
+	node, err := rawValue(s)
+	if err != nil {
+		return nil, err
+	}
+	if s.skipSpace(); s.pos < len(s.data) {
+		return nil, s.errorf("invalid character %q after top-level value", s.data[s.pos])
+	}
+	return node, nil
+}
+
+func rawValue(s *jsonScanner) (*rawNode, error) {
+	var err error
+	node := &rawNode{}
+	c := s.peek()
+	start := s.pos
+	switch c {
+	case '{':
+		more := false
+		node.members = []rawMember{}
+		more, err = s.enter('{', '}')
+		for err == nil && more {
+			var raw []byte
+			var escaped bool
+			var m rawMember
+			if raw, escaped, err = s.scanString(); err == nil {
+				m.key, err = unquote(raw, escaped)
+			}
+			if err == nil {
+				err = s.expect(':')
+			}
+			if err == nil {
+				m.value, err = rawValue(s)
+			}
+			if err == nil {
+				node.members = append(node.members, m)
+				more, err = s.more('}')
+			}
+		}
+	case '[':
+		more := false
+		node.items = []*rawNode{}
+		more, err = s.enter('[', ']')
+		for err == nil && more {
+			var item *rawNode
+			if item, err = rawValue(s); err == nil {
+				node.items = append(node.items, item)
+				more, err = s.more(']')
+			}
+		}
+	default:
+		_, err = s.skipValue()
+	}
+	if err != nil {
+		return nil, err
+	}
+	node.raw = s.data[start:s.pos]
+	return node, nil
+}
@@ -0,0 +1,134 @@
+package pokesdk_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/danielgtaylor/pokesdk"
+)
+
+const extraPokemon = `{"id":25,"name":"pikachu","is_cute":true,"nicknames":["sparky"],"sprites":{"front_default":"u","other":{"home":{"front_default":"h","front_glitter":"g"}}}}`
+
+func TestExtraFields(t *testing.T) {
+	transport := &mockTransport{}
+	transport.Expect("https://pokeapi.co/api/v2/pokemon/pikachu", http.StatusOK, extraPokemon)
+
+	sdk := pokesdk.New(pokesdk.Config{
+		Client:            &http.Client{Transport: transport},
+		KeepUnknownFields: true,
+	})
+
+	pika, err := sdk.GetPokemon(context.Background(), "pikachu")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if pika.Name != "pikachu" {
+		t.Errorf("expected pikachu, got %s", pika.Name)
+	}
+
+	if string(pika.Extra["is_cute"]) != "true" || len(pika.Extra) != 2 {
+		t.Errorf("unexpected extra fields: %v", pika.Extra)
+	}
+
+	b, err := json.Marshal(pika)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded map[string]any
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if decoded["is_cute"] != true || decoded["name"] != "pikachu" || decoded["nicknames"] == nil {
+		t.Errorf("expected extra fields to be re-encoded: %s", b)
+	}
+
+	if _, ok := decoded["Extra"]; ok {
+		t.Errorf("expected Extra not to be encoded directly")
+	}
+}
+
+func TestExtraFieldsRaw(t *testing.T) {
+	transport := &mockTransport{}
+	transport.Expect("https://pokeapi.co/api/v2/pokemon/pikachu", http.StatusOK, `{
+		"name": "pikachu",
+		"big_id": 12345678901234567891,
+		"link": "<a href=\"?a=1&b=2\">",
+		"sprites": {"other": {"home": {"front_glitter": "a&b"}}}
+	}`)
+
+	sdk := pokesdk.New(pokesdk.Config{
+		Client:            &http.Client{Transport: transport},
+		KeepUnknownFields: true,
+	})
+
+	pika, err := sdk.GetPokemon(context.Background(), "pikachu")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(pika); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []string{
+		`"big_id":12345678901234567891`,
+		`"link":"<a href=\"?a=1&b=2\">"`,
+		`"front_glitter":"a&b"`,
+	} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("expected %s in %s", expected, buf)
+		}
+	}
+}
+
+func TestExtraFieldsOptIn(t *testing.T) {
+	transport := &mockTransport{}
+	transport.Expect("https://pokeapi.co/api/v2/pokemon/pikachu", http.StatusOK, extraPokemon)
+
+	sdk := pokesdk.New(pokesdk.Config{
+		Client: &http.Client{Transport: transport},
+	})
+
+	pika, err := sdk.GetPokemon(context.Background(), "pikachu")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if pika.Extra != nil {
+		t.Errorf("expected no extra fields by default: %v", pika.Extra)
+	}
+
+	// Unknown sprite keys are always kept.
+	if string(pika.Sprites.Other.Home.Extra["front_glitter"]) != `"g"` {
+		t.Errorf("expected unknown sprite key: %v", pika.Sprites.Other.Home.Extra)
+	}
+}
+
+func TestExtraFieldsPointer(t *testing.T) {
+	// Encoding through a pointer must use the same custom encoder.
+	gen := &pokesdk.Generation{Name: "generation-i", Extra: map[string]json.RawMessage{"year": json.RawMessage("1996")}}
+
+	b, err := json.Marshal(gen)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded map[string]any
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if decoded["year"] != float64(1996) {
+		t.Errorf("expected year to round trip: %s", b)
+	}
+}
@@ -0,0 +1,1032 @@
+package pokesdk
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// This file implements the decoder used by `JSONCodec` in builds with the
+// `pokesdk_fastjson` tag. It parses and decodes in a single pass using
+// per-type decoders which are built once, and reuses identical strings within
+// a response. API responses repeat the same names and URLs many times, e.g.
+// for the version groups of every move, so this saves most of the allocations
+// of `encoding/json`. Types it does not handle, such as those implementing
+// `encoding.TextUnmarshaler`, are decoded with `encoding/json`.
+
+// maxJSONDepth limits the nesting of objects and arrays, like `encoding/json`.
+const maxJSONDepth = 10000
+
+// maxLinearFields is the most fields a struct may have to look up object
+// keys by comparing them with each field name.
+const maxLinearFields = 8
+
+// maxInternLength is the longest string which is reused within a response.
+const maxInternLength = 128
+
+// jsonScanner reads JSON tokens from a byte slice.
+type jsonScanner struct {
+	data  []byte
+	pos   int
+	depth int
+}
+
+func (s *jsonScanner) errorf(format string, args ...any) error {
+	return fmt.Errorf("invalid JSON at offset %d: %s", s.pos, fmt.Sprintf(format, args...))
+}
+
+func (s *jsonScanner) skipSpace() {
+	for s.pos < len(s.data) {
+		switch s.data[s.pos] {
+		case ' ', '\t', '\n', '\r':
+			s.pos++
+		default:
+			return
+		}
+	}
+}
+
+// peek returns the next byte after any whitespace, or zero at the end of the
+// data.
+func (s *jsonScanner) peek() byte {
+	s.skipSpace()
+	if s.pos >= len(s.data) {
+		return 0
+	}
+	return s.data[s.pos]
+}
+
+// expect consumes `c` after any whitespace.
+func (s *jsonScanner) expect(c byte) error {
+	if s.peek() != c {
+		if s.pos >= len(s.data) {
+			return s.errorf("unexpected end of data")
+		}
+		return s.errorf("expected %q but found %q", c, s.data[s.pos])
+	}
+	s.pos++
+	return nil
+}
+
+// enter consumes the opening `start` of an object or array and returns
+// whether it has any members or items. See `more`.
+func (s *jsonScanner) enter(start, end byte) (bool, error) {
+	if err := s.expect(start); err != nil {
+		return false, err
+	}
+	if s.depth++; s.depth > maxJSONDepth {
+		return false, s.errorf("exceeded max depth")
+	}
+	if s.peek() == end {
+		s.pos++
+		s.depth--
+		return false, nil
+	}
+	return true, nil
+}
+
+// more consumes a comma or the closing `end` of an object or array, and
+// returns whether another member or item follows.
+func (s *jsonScanner) more(end byte) (bool, error) {
+	switch s.peek() {
+	case ',':
+		s.pos++
+		return true, nil
+	case end:
+		s.pos++
+		s.depth--
+		return false, nil
+	}
+	return false, s.expect(end)
+}
+
+// plainStringByte reports which bytes need no special handling in strings.
+var plainStringByte = func() (t [256]bool) {
+	for c := 0x20; c < 256; c++ {
+		t[c] = c != '"' && c != '\\'
+	}
+	return t
+}()
+
+// scanString consumes a string and returns the bytes between the quotes and
+// whether they contain escapes. See `unquote`.
+func (s *jsonScanner) scanString() ([]byte, bool, error) {
+	if s.peek() != '"' {
+		return nil, false, s.errorf("expected string")
+	}
+
+	escaped := false
+	start := s.pos + 1
+	for i := start; i < len(s.data); i++ {
+		if c := s.data[i]; plainStringByte[c] {
+			continue
+		}
+		switch c := s.data[i]; {
+		case c == '"':
+			s.pos = i + 1
+			return s.data[start:i], escaped, nil
+		case c == '\\':
+			escaped = true
+			if i++; i >= len(s.data) || strings.IndexByte(`"\\/bfnrtu`, s.data[i]) < 0 {
+				s.pos = i
+				return nil, false, s.errorf("invalid escape in string")
+			}
+			if s.data[i] == 'u' {
+				if _, ok := unhex(s.data[i+1:]); !ok {
+					s.pos = i
+					return nil, false, s.errorf("invalid escape in string")
+				}
+			}
+		case c < 0x20:
+			s.pos = i
+			return nil, false, s.errorf("invalid character %q in string", c)
+		}
+	}
+	s.pos = len(s.data)
+	return nil, false, s.errorf("unterminated string")
+}
+
+// scanNumber consumes a number and returns its bytes.
+func (s *jsonScanner) scanNumber() ([]byte, error) {
+	start := s.pos
+	digits := func() bool {
+		n := s.pos
+		for s.pos < len(s.data) && s.data[s.pos] >= '0' && s.data[s.pos] <= '9' {
+			s.pos++
+		}
+		return s.pos > n
+	}
+	next := func(chars string) bool {
+		if s.pos < len(s.data) && strings.IndexByte(chars, s.data[s.pos]) >= 0 {
+			s.pos++
+			return true
+		}
+		return false
+	}
+
+	next("-")
+	if !next("0") && !digits() {
+		return nil, s.errorf("invalid number")
+	}
+	if next(".") && !digits() {
+		return nil, s.errorf("invalid number")
+	}
+	if next("eE") {
+		next("+-")
+		if !digits() {
+			return nil, s.errorf("invalid number")
+		}
+	}
+	return s.data[start:s.pos], nil
+}
+
+// scanLiteral consumes `true`, `false` or `null`.
+func (s *jsonScanner) scanLiteral(lit string) error {
+	if len(s.data)-s.pos < len(lit) || string(s.data[s.pos:s.pos+len(lit)]) != lit {
+		return s.errorf("invalid literal")
+	}
+	s.pos += len(lit)
+	return nil
+}
+
+// skipValue consumes any value and returns its bytes.
+func (s *jsonScanner) skipValue() ([]byte, error) {
+	var err error
+	c := s.peek()
+	start := s.pos
+	switch c {
+	case '{', '[':
+		end := byte('}')
+		if c == '[' {
+			end = ']'
+		}
+		more := false
+		more, err = s.enter(c, end)
+		for err == nil && more {
+			if c == '{' {
+				if _, _, err = s.scanString(); err == nil {
+					err = s.expect(':')
+				}
+			}
+			if err == nil {
+				_, err = s.skipValue()
+			}
+			if err == nil {
+				more, err = s.more(end)
+			}
+		}
+	case '"':
+		_, _, err = s.scanString()
+	case 't':
+		err = s.scanLiteral("true")
+	case 'f':
+		err = s.scanLiteral("false")
+	case 'n':
+		err = s.scanLiteral("null")
+	case 0:
+		err = s.errorf("unexpected end of data")
+	default:
+		_, err = s.scanNumber()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.data[start:s.pos], nil
+}
+
+// unquote returns the value of a string from `scanString`, replacing invalid
+// UTF-8 and escapes like `encoding/json` does.
+func unquote(raw []byte, escaped bool) (string, error) {
+	if !escaped && utf8.Valid(raw) {
+		return string(raw), nil
+	}
+
+	b := make([]byte, 0, len(raw))
+	for i := 0; i < len(raw); {
+		c := raw[i]
+		switch {
+		case c == '\\':
+			if i+1 >= len(raw) {
+				return "", fmt.Errorf("invalid escape in string")
+			}
+			switch e := raw[i+1]; e {
+			case '"', '\\', '/':
+				b = append(b, e)
+			case 'b':
+				b = append(b, '\b')
+			case 'f':
+				b = append(b, '\f')
+			case 'n':
+				b = append(b, '\n')
+			case 'r':
+				b = append(b, '\r')
+			case 't':
+				b = append(b, '\t')
+			case 'u':
+				r, ok := unhex(raw[i+2:])
+				if !ok {
+					return "", fmt.Errorf("invalid escape in string")
+				}
+				i += 6
+				if utf16.IsSurrogate(r) {
+					// Combine surrogate pairs, or replace a lone surrogate
+					// without consuming what follows it.
+					r2, ok := rune(0), false
+					if i+1 < len(raw) && raw[i] == '\\' && raw[i+1] == 'u' {
+						r2, ok = unhex(raw[i+2:])
+					}
+					if dec := utf16.DecodeRune(r, r2); ok && dec != utf8.RuneError {
+						r = dec
+						i += 6
+					} else {
+						r = utf8.RuneError
+					}
+				}
+				b = utf8.AppendRune(b, r)
+				continue
+			default:
+				return "", fmt.Errorf("invalid escape in string")
+			}
+			i += 2
+		case c < utf8.RuneSelf:
+			b = append(b, c)
+			i++
+		default:
+			r, size := utf8.DecodeRune(raw[i:])
+			if r == utf8.RuneError && size == 1 {
+				b = utf8.AppendRune(b, utf8.RuneError)
+			} else {
+				b = append(b, raw[i:i+size]...)
+			}
+			i += size
+		}
+	}
+	return string(b), nil
+}
+
+// unhex parses the four hex digits at the start of `b`.
+func unhex(b []byte) (rune, bool) {
+	if len(b) < 4 {
+		return 0, false
+	}
+	var r rune
+	for _, c := range b[:4] {
+		switch {
+		case c >= '0' && c <= '9':
+			c -= '0'
+		case c >= 'a' && c <= 'f':
+			c = c - 'a' + 10
+		case c >= 'A' && c <= 'F':
+			c = c - 'A' + 10
+		default:
+			return 0, false
+		}
+		r = r<<4 | rune(c)
+	}
+	return r, true
+}
+
+// fastDecoder holds the state of a single `unmarshalFast` call.
+type fastDecoder struct {
+	jsonScanner
+
+	// strings are the short strings seen so far, which are reused.
+	strings map[string]string
+
+	// typeErr is the first value which did not fit the Go type. As with
+	// `encoding/json`, decoding continues and it is returned at the end.
+	typeErr error
+}
+
+// unmarshalFast decodes JSON like `json.Unmarshal`.
+func unmarshalFast(data []byte, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return &json.InvalidUnmarshalError{Type: reflect.TypeOf(v)}
+	}
+
+	d := &fastDecoder{jsonScanner: jsonScanner{data: data}, strings: map[string]string{}}
+	if err := decoderFor(rv.Type().Elem())(d, rv.Elem()); err != nil {
+		return err
+	}
+	if d.skipSpace(); d.pos < len(d.data) {
+		return d.errorf("invalid character %q after top-level value", d.data[d.pos])
+	}
+	return d.typeErr
+}
+
+// string consumes a string and returns its value.
+func (d *fastDecoder) string() (string, error) {
+	raw, escaped, err := d.scanString()
+	if err != nil {
+		return "", err
+	}
+	if escaped || len(raw) > maxInternLength {
+		return unquote(raw, escaped)
+	}
+	if s, ok := d.strings[string(raw)]; ok {
+		return s, nil
+	}
+	s, err := unquote(raw, false)
+	if err == nil {
+		d.strings[s] = s
+	}
+	return s, err
+}
+
+// mismatch skips a value which does not fit type `t` and records the error.
+func (d *fastDecoder) mismatch(t reflect.Type) error {
+	offset := d.pos
+	raw, err := d.skipValue()
+	if err != nil {
+		return err
+	}
+	if d.typeErr == nil {
+		value := "number " + string(raw)
+		switch raw[0] {
+		case '{':
+			value = "object"
+		case '[':
+			value = "array"
+		case '"':
+			value = "string"
+		case 't', 'f':
+			value = "bool"
+		}
+		d.typeErr = &json.UnmarshalTypeError{Value: value, Type: t, Offset: int64(offset)}
+	}
+	return nil
+}
+
+// decodeFunc decodes the next value into `v`, which is addressable.
+type decodeFunc func(d *fastDecoder, v reflect.Value) error
+
+var decoders sync.Map
+
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// decoderFor returns the cached decoder for type `t`, building it if needed.
+func decoderFor(t reflect.Type) decodeFunc {
+	if f, ok := decoders.Load(t); ok {
+		return f.(decodeFunc)
+	}
+
+	// Recursive types refer to their own decoder while it is being built, so
+	// store one which waits for it to be ready.
+	var (
+		wg sync.WaitGroup
+		f  decodeFunc
+	)
+	wg.Add(1)
+	fi, loaded := decoders.LoadOrStore(t, decodeFunc(func(d *fastDecoder, v reflect.Value) error {
+		wg.Wait()
+		return f(d, v)
+	}))
+	if loaded {
+		return fi.(decodeFunc)
+	}
+
+	f = newDecoder(t)
+	wg.Done()
+	decoders.Store(t, f)
+	return f
+}
+
+func newDecoder(t reflect.Type) decodeFunc {
+	if t == rawMessageType {
+		return decodeRawMessage
+	}
+	if t.Kind() != reflect.Pointer {
+		if reflect.PointerTo(t).Implements(unmarshalerType) {
+			return decodeUnmarshaler
+		}
+		if reflect.PointerTo(t).Implements(textUnmarshalerType) {
+			return decodeFallback
+		}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return newPointerDecoder(t)
+	case reflect.Struct:
+		return newStructDecoder(t)
+	case reflect.Slice:
+		if t.Elem().Kind() != reflect.Uint8 {
+			return newSliceDecoder(t)
+		}
+	case reflect.Array:
+		return newArrayDecoder(t)
+	case reflect.Map:
+		if t.Key().Kind() == reflect.String && !reflect.PointerTo(t.Key()).Implements(textUnmarshalerType) {
+			return newMapDecoder(t)
+		}
+	case reflect.Interface:
+		if t.NumMethod() == 0 {
+			return decodeInterface
+		}
+	case reflect.String:
+		return decodeString
+	case reflect.Bool:
+		return decodeBool
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return decodeInt
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return decodeUint
+	case reflect.Float32, reflect.Float64:
+		return decodeFloat
+	}
+	return decodeFallback
+}
+
+// decodeFallback decodes the next value with `encoding/json`.
+func decodeFallback(d *fastDecoder, v reflect.Value) error {
+	raw, err := d.skipValue()
+	if err != nil {
+		return err
+	}
+	err = json.Unmarshal(raw, v.Addr().Interface())
+	if typeErr, ok := err.(*json.UnmarshalTypeError); ok {
+		if d.typeErr == nil {
+			d.typeErr = typeErr
+		}
+		return nil
+	}
+	return err
+}
+
+func decodeRawMessage(d *fastDecoder, v reflect.Value) error {
+	raw, err := d.skipValue()
+	if err != nil {
+		return err
+	}
+	v.SetBytes(append(v.Bytes()[:0], raw...))
+	return nil
+}
+
+func decodeUnmarshaler(d *fastDecoder, v reflect.Value) error {
+	raw, err := d.skipValue()
+	if err != nil {
+		return err
+	}
+	return v.Addr().Interface().(json.Unmarshaler).UnmarshalJSON(raw)
+}
+
+func newPointerDecoder(t reflect.Type) decodeFunc {
+	elem := decoderFor(t.Elem())
+	return func(d *fastDecoder, v reflect.Value) error {
+		if d.peek() == 'n' {
+			v.SetZero()
+			return d.scanLiteral("null")
+		}
+		if v.IsNil() {
+			v.Set(reflect.New(t.Elem()))
+		}
+		return elem(d, v.Elem())
+	}
+}
+
+// fastField is a struct field which JSON object members are decoded into.
+type fastField struct {
+	name   string
+	index  []int
+	decode decodeFunc
+}
+
+func newStructDecoder(t reflect.Type) decodeFunc {
+	fields, ok := decodableFields(t)
+	if !ok {
+		return decodeFallback
+	}
+
+	byName := make(map[string]*fastField, len(fields))
+	for _, f := range fields {
+		byName[f.name] = f
+	}
+	for _, f := range fields {
+		f.decode = decoderFor(t.FieldByIndex(f.index).Type)
+	}
+
+	return func(d *fastDecoder, v reflect.Value) error {
+		if d.peek() != '{' {
+			return d.nullOr(v, t, false)
+		}
+
+		more, err := d.enter('{', '}')
+		for err == nil && more {
+			var raw []byte
+			var escaped bool
+			if raw, escaped, err = d.scanString(); err != nil {
+				return err
+			}
+			var f *fastField
+			if len(fields) <= maxLinearFields {
+				// Comparing a few names is faster than hashing the key.
+				for _, candidate := range fields {
+					if candidate.name == string(raw) {
+						f = candidate
+						break
+					}
+				}
+			} else {
+				f = byName[string(raw)]
+			}
+			if f == nil {
+				f, err = fold(fields, byName, raw, escaped)
+			}
+			if err == nil {
+				err = d.expect(':')
+			}
+			if err != nil {
+				return err
+			}
+
+			if fv, ok := fieldByIndex(v, f); ok {
+				err = f.decode(d, fv)
+			} else {
+				_, err = d.skipValue()
+			}
+			if err == nil {
+				more, err = d.more('}')
+			}
+		}
+		return err
+	}
+}
+
+// fold finds the field for an object key which has escapes or differs in
+// case, like `encoding/json` does. It returns nil for unknown keys.
+func fold(fields []*fastField, byName map[string]*fastField, raw []byte, escaped bool) (*fastField, error) {
+	key, err := unquote(raw, escaped)
+	if err != nil {
+		return nil, err
+	}
+	if f := byName[key]; f != nil {
+		return f, nil
+	}
+	for _, f := range fields {
+		if strings.EqualFold(f.name, key) {
+			return f, nil
+		}
+	}
+	return nil, nil
+}
+
+// fieldByIndex returns the struct field for `f`, allocating embedded struct
+// pointers as needed. It returns false for unknown keys, or if an unexported
+// embedded pointer is nil.
+func fieldByIndex(v reflect.Value, f *fastField) (reflect.Value, bool) {
+	if f == nil {
+		return v, false
+	}
+	for i, x := range f.index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return v, false
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+// decodableFields returns the fields of a struct type which JSON object
+// members are decoded into, following the rules of `encoding/json` for
+// embedded structs and conflicting names. It returns false for structs using
+// options this decoder does not support.
+func decodableFields(t reflect.Type) ([]*fastField, bool) {
+	type candidate struct {
+		fastField
+		tagged bool
+	}
+
+	candidates := []candidate{}
+	visited := map[reflect.Type]bool{}
+	next := []candidate{{fastField: fastField{index: nil}}}
+	types := []reflect.Type{t}
+	for len(types) > 0 {
+		current, currentTypes := next, types
+		next, types = nil, nil
+		for n, st := range currentTypes {
+			if visited[st] {
+				continue
+			}
+			visited[st] = true
+
+			for i := 0; i < st.NumField(); i++ {
+				sf := st.Field(i)
+				ft := sf.Type
+				if ft.Name() == "" && ft.Kind() == reflect.Pointer {
+					ft = ft.Elem()
+				}
+				if sf.Anonymous {
+					if !sf.IsExported() && ft.Kind() != reflect.Struct {
+						continue
+					}
+				} else if !sf.IsExported() {
+					continue
+				}
+
+				tag := sf.Tag.Get("json")
+				if tag == "-" {
+					continue
+				}
+				name, opts, _ := strings.Cut(tag, ",")
+				index := append(append([]int(nil), current[n].index...), i)
+
+				if name == "" && sf.Anonymous && ft.Kind() == reflect.Struct {
+					next = append(next, candidate{fastField: fastField{index: index}})
+					types = append(types, ft)
+					continue
+				}
+				if strings.Contains(","+opts+",", ",string,") {
+					return nil, false
+				}
+
+				c := candidate{fastField: fastField{name: name, index: index}, tagged: name != ""}
+				if name == "" {
+					c.name = sf.Name
+				}
+				candidates = append(candidates, c)
+			}
+		}
+	}
+
+	// The shallowest field with a name wins, or the only tagged one of
+	// several at the same depth. Otherwise the name is ambiguous and ignored.
+	byName := map[string][]candidate{}
+	names := []string{}
+	for _, c := range candidates {
+		if byName[c.name] == nil {
+			names = append(names, c.name)
+		}
+		byName[c.name] = append(byName[c.name], c)
+	}
+
+	fields := []*fastField{}
+	for _, name := range names {
+		var dominant []candidate
+		for _, c := range byName[name] {
+			switch {
+			case len(dominant) == 0 || len(c.index) < len(dominant[0].index):
+				dominant = []candidate{c}
+			case len(c.index) == len(dominant[0].index):
+				dominant = append(dominant, c)
+			}
+		}
+		if len(dominant) > 1 {
+			tagged := dominant[:0:0]
+			for _, c := range dominant {
+				if c.tagged {
+					tagged = append(tagged, c)
+				}
+			}
+			dominant = tagged
+		}
+		if len(dominant) == 1 {
+			f := dominant[0].fastField
+			fields = append(fields, &f)
+		}
+	}
+	return fields, true
+}
+
+func newSliceDecoder(t reflect.Type) decodeFunc {
+	elem := decoderFor(t.Elem())
+	return func(d *fastDecoder, v reflect.Value) error {
+		if d.peek() != '[' {
+			return d.nullOr(v, t, true)
+		}
+
+		more, err := d.enter('[', ']')
+		if err != nil {
+			return err
+		}
+		if v.IsNil() {
+			v.Set(reflect.MakeSlice(t, 0, 0))
+		}
+
+		i := 0
+		for ; more; i++ {
+			if i >= v.Cap() {
+				grown := reflect.MakeSlice(t, v.Len(), max(4, v.Cap()*2))
+				reflect.Copy(grown, v)
+				v.Set(grown)
+			}
+			if i >= v.Len() {
+				v.SetLen(i + 1)
+				v.Index(i).SetZero()
+			}
+			if err := elem(d, v.Index(i)); err != nil {
+				return err
+			}
+			if more, err = d.more(']'); err != nil {
+				return err
+			}
+		}
+		v.SetLen(i)
+		return nil
+	}
+}
+
+func newArrayDecoder(t reflect.Type) decodeFunc {
+	elem := decoderFor(t.Elem())
+	return func(d *fastDecoder, v reflect.Value) error {
+		if d.peek() != '[' {
+			return d.nullOr(v, t, false)
+		}
+
+		more, err := d.enter('[', ']')
+		i := 0
+		for ; err == nil && more; i++ {
+			if i < v.Len() {
+				err = elem(d, v.Index(i))
+			} else {
+				_, err = d.skipValue()
+			}
+			if err == nil {
+				more, err = d.more(']')
+			}
+		}
+		for ; i < v.Len(); i++ {
+			v.Index(i).SetZero()
+		}
+		return err
+	}
+}
+
+func newMapDecoder(t reflect.Type) decodeFunc {
+	elem := decoderFor(t.Elem())
+	return func(d *fastDecoder, v reflect.Value) error {
+		if d.peek() != '{' {
+			return d.nullOr(v, t, true)
+		}
+
+		if v.IsNil() {
+			v.Set(reflect.MakeMap(t))
+		}
+		value := reflect.New(t.Elem()).Elem()
+		more, err := d.enter('{', '}')
+		for err == nil && more {
+			var key string
+			if key, err = d.string(); err == nil {
+				err = d.expect(':')
+			}
+			if err == nil {
+				value.SetZero()
+				err = elem(d, value)
+			}
+			if err == nil {
+				v.SetMapIndex(reflect.ValueOf(key).Convert(t.Key()), value)
+				more, err = d.more('}')
+			}
+		}
+		return err
+	}
+}
+
+// nullOr handles a value which is not of the kind `t` expects: `null` is
+// skipped, setting `v` to its zero value if `clear` is true, and anything
+// else is a mismatch.
+func (d *fastDecoder) nullOr(v reflect.Value, t reflect.Type, clear bool) error {
+	if d.peek() != 'n' {
+		return d.mismatch(t)
+	}
+	if clear {
+		v.SetZero()
+	}
+	return d.scanLiteral("null")
+}
+
+func decodeInterface(d *fastDecoder, v reflect.Value) error {
+	if !v.IsNil() && v.Elem().Kind() == reflect.Pointer {
+		// Decode into the existing value like `encoding/json`.
+		return decodeFallback(d, v)
+	}
+	value, err := d.any()
+	if err != nil {
+		return err
+	}
+	if value == nil {
+		v.SetZero()
+	} else {
+		v.Set(reflect.ValueOf(value))
+	}
+	return nil
+}
+
+// any decodes the next value into the types used by `encoding/json` for an
+// empty interface.
+func (d *fastDecoder) any() (any, error) {
+	switch c := d.peek(); c {
+	case '{':
+		obj := map[string]any{}
+		more, err := d.enter('{', '}')
+		for err == nil && more {
+			var key string
+			var value any
+			if key, err = d.string(); err == nil {
+				err = d.expect(':')
+			}
+			if err == nil {
+				value, err = d.any()
+			}
+			if err == nil {
+				obj[key] = value
+				more, err = d.more('}')
+			}
+		}
+		return obj, err
+	case '[':
+		items := []any{}
+		more, err := d.enter('[', ']')
+		for err == nil && more {
+			var item any
+			if item, err = d.any(); err == nil {
+				items = append(items, item)
+				more, err = d.more(']')
+			}
+		}
+		return items, err
+	case '"':
+		return d.string()
+	case 't':
+		return true, d.scanLiteral("true")
+	case 'f':
+		return false, d.scanLiteral("false")
+	case 'n':
+		return nil, d.scanLiteral("null")
+	case 0:
+		return nil, d.errorf("unexpected end of data")
+	}
+
+	raw, err := d.scanNumber()
+	if err != nil {
+		return nil, err
+	}
+	return strconv.ParseFloat(string(raw), 64)
+}
+
+func decodeString(d *fastDecoder, v reflect.Value) error {
+	switch d.peek() {
+	case '"':
+		s, err := d.string()
+		if err != nil {
+			return err
+		}
+		v.SetString(s)
+		return nil
+	case 'n':
+		return d.scanLiteral("null")
+	}
+	return d.mismatch(v.Type())
+}
+
+func decodeBool(d *fastDecoder, v reflect.Value) error {
+	switch d.peek() {
+	case 't':
+		v.SetBool(true)
+		return d.scanLiteral("true")
+	case 'f':
+		v.SetBool(false)
+		return d.scanLiteral("false")
+	case 'n':
+		return d.scanLiteral("null")
+	}
+	return d.mismatch(v.Type())
+}
+
+// number consumes a number for a value of type `t`. It returns false after
+// recording a mismatch, or for `null`, which leaves the value unchanged.
+func (d *fastDecoder) number(t reflect.Type) ([]byte, bool, error) {
+	switch c := d.peek(); {
+	case c == 'n':
+		return nil, false, d.scanLiteral("null")
+	case c == '-' || (c >= '0' && c <= '9'):
+		raw, err := d.scanNumber()
+		return raw, err == nil, err
+	}
+	return nil, false, d.mismatch(t)
+}
+
+// numberError records a number which does not fit type `t`.
+func (d *fastDecoder) numberError(raw []byte, t reflect.Type) {
+	if d.typeErr == nil {
+		d.typeErr = &json.UnmarshalTypeError{Value: "number " + string(raw), Type: t, Offset: int64(d.pos - len(raw))}
+	}
+}
+
+func decodeInt(d *fastDecoder, v reflect.Value) error {
+	raw, ok, err := d.number(v.Type())
+	if !ok {
+		return err
+	}
+
+	digits, negative := raw, raw[0] == '-'
+	if negative {
+		digits = raw[1:]
+	}
+	var n uint64
+	for _, c := range digits {
+		if c < '0' || c > '9' || n > (math.MaxUint64-9)/10 {
+			d.numberError(raw, v.Type())
+			return nil
+		}
+		n = n*10 + uint64(c-'0')
+	}
+
+	limit := uint64(math.MaxInt64)
+	if negative {
+		limit++
+	}
+	i := int64(n)
+	if negative {
+		i = -i
+	}
+	if n > limit || v.OverflowInt(i) {
+		d.numberError(raw, v.Type())
+		return nil
+	}
+	v.SetInt(i)
+	return nil
+}
+
+func decodeUint(d *fastDecoder, v reflect.Value) error {
+	raw, ok, err := d.number(v.Type())
+	if !ok {
+		return err
+	}
+
+	n, err := strconv.ParseUint(string(raw), 10, 64)
+	if err != nil || v.OverflowUint(n) {
+		d.numberError(raw, v.Type())
+		return nil
+	}
+	v.SetUint(n)
+	return nil
+}
+
+func decodeFloat(d *fastDecoder, v reflect.Value) error {
+	raw, ok, err := d.number(v.Type())
+	if !ok {
+		return err
+	}
+
+	f, err := strconv.ParseFloat(string(raw), v.Type().Bits())
+	if err != nil || v.OverflowFloat(f) {
+		d.numberError(raw, v.Type())
+		return nil
+	}
+	v.SetFloat(f)
+	return nil
+}
@@ -0,0 +1,151 @@
+package pokesdk_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/danielgtaylor/pokesdk"
+)
+
+type fastInner struct {
+	Name  string `json:"name"`
+	Value int
+}
+
+type FastEmbedded struct {
+	Embedded string `json:"embedded"`
+	Shadowed string `json:"shadowed"`
+}
+
+type fastOuter struct {
+	FastEmbedded
+	*fastInner `json:"-"`
+	Shadowed   string             `json:"shadowed"`
+	Inner      fastInner          `json:"inner"`
+	Ptr        *fastInner         `json:"ptr"`
+	List       []fastInner        `json:"list"`
+	Array      [2]int             `json:"array"`
+	Map        map[string]int     `json:"map"`
+	Any        any                `json:"any"`
+	Raw        json.RawMessage    `json:"raw"`
+	Bytes      []byte             `json:"bytes"`
+	Float      float64            `json:"float"`
+	Small      int8               `json:"small"`
+	Unsigned   uint               `json:"unsigned"`
+	Bool       bool               `json:"bool"`
+	Links      []pokesdk.TypeLink `json:"links"`
+	ignored    string
+}
+
+// TestUnmarshalFast checks that the `pokesdk_fastjson` decoder gives the same
+// results and errors as `encoding/json`.
+func TestUnmarshalFast(t *testing.T) {
+	inputs := []string{
+		`{}`,
+		`null`,
+		`{"embedded":"e","shadowed":"outer","inner":{"name":"n","Value":1}}`,
+		`{"NAME":"x","inner":{"NAME":"folded","value":2},"EMBEDDED":"y"}`,
+		`{"ptr":{"name":"p"},"list":[{"name":"a"},{"name":"b"}],"array":[1,2,3]}`,
+		`{"ptr":null,"list":null,"map":null,"any":null}`,
+		`{"array":[1]}`,
+		`{"map":{"a":1,"b":2},"any":{"x":[1,"two",true,null,{"y":1.5}]}}`,
+		`{"raw": {"keep" : [1, 2]} , "bytes":"aGVsbG8="}`,
+		`{"float":-1.5e3,"small":-128,"unsigned":42,"bool":true}`,
+		`{"inner":{"name":"é😀 \"quoted\" \\ \/ \b\f\n\r\t"}}`,
+		`{"inner":{"name":"lone \ud83d surrogate \udc00"}}`,
+		"{\"inner\":{\"name\":\"invalid \xff utf-8\"}}",
+		`{"links":[{"name":"electric","url":"u"},{"name":"electric","url":"u"}]}`,
+		`{"unknown":{"deep":[{"a":[[]]}]},"name":"after"}`,
+		" \n\t{ \"inner\" : { \"name\" : \"spaced\" } } \n",
+		`{"small":300,"name":"continues"}`,
+		`{"small":1.5}`,
+		`{"unsigned":-1}`,
+		`{"bool":"true","inner":"x","list":{},"map":[]}`,
+		`{"float":"1"}`,
+		`{"inner":{"name":1}}`,
+		`{"name":"x",}`,
+		`{"name":"x"`,
+		`{"name":"x"} trailing`,
+		`{"name":tru}`,
+		`{"small":01}`,
+		`{"small":-}`,
+		`{"inner":{"name":"unterminated}}`,
+		"{\"inner\":{\"name\":\"control \x01\"}}",
+		`{"inner":{"name":"bad \x escape"}}`,
+		`[1,2]`,
+		``,
+		strings.Repeat(`{"any":`, 20000) + `1` + strings.Repeat(`}`, 20000),
+	}
+
+	for _, input := range inputs {
+		var expected, actual fastOuter
+		expectedErr := json.Unmarshal([]byte(input), &expected)
+		actualErr := pokesdk.UnmarshalFast([]byte(input), &actual)
+
+		name := input
+		if len(name) > 60 {
+			name = name[:60]
+		}
+
+		if (expectedErr == nil) != (actualErr == nil) {
+			t.Errorf("%s: expected error %v, got %v", name, expectedErr, actualErr)
+			continue
+		}
+
+		var expectedType *json.UnmarshalTypeError
+		var actualType *json.UnmarshalTypeError
+		if errors.As(expectedErr, &expectedType) != errors.As(actualErr, &actualType) {
+			t.Errorf("%s: expected error %v, got %v", name, expectedErr, actualErr)
+		}
+
+		if (expectedErr == nil || expectedType != nil) && !reflect.DeepEqual(expected, actual) {
+			t.Errorf("%s: expected %+v, got %+v", name, expected, actual)
+		}
+	}
+}
+
+func TestUnmarshalFastPokemon(t *testing.T) {
+	data, err := os.ReadFile("testdata/pokemon-pikachu.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var expected, actual pokesdk.Pokemon
+	if err := json.Unmarshal(data, &expected); err != nil {
+		t.Fatal(err)
+	}
+	if err := pokesdk.UnmarshalFast(data, &actual); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected the same Pokemon as encoding/json")
+	}
+
+	if err := pokesdk.UnmarshalFast(data, actual); err == nil {
+		t.Errorf("expected error for non-pointer")
+	}
+}
+
+func FuzzUnmarshalFast(f *testing.F) {
+	f.Add(`{"name":"pikachu","id":25,"types":[{"slot":1,"type":{"name":"electric"}}]}`)
+	f.Add(`{"sprites":{"front_default":"u","other":{"home":{"front_default":null}}}}`)
+	f.Add(`{"moves":[{"version_group_details":[{"level_learned_at":1.5}]}],"cries":{"a":"b"}}`)
+
+	f.Fuzz(func(t *testing.T, input string) {
+		var expected, actual pokesdk.Pokemon
+		expectedErr := json.Unmarshal([]byte(input), &expected)
+		actualErr := pokesdk.UnmarshalFast([]byte(input), &actual)
+
+		if (expectedErr == nil) != (actualErr == nil) {
+			t.Fatalf("expected error %v, got %v", expectedErr, actualErr)
+		}
+		if expectedErr == nil && !reflect.DeepEqual(expected, actual) {
+			t.Fatalf("expected %+v, got %+v", expected, actual)
+		}
+	})
+}
@@ -1,6 +1,9 @@
 package pokesdk
 
-import "context"
+import (
+	"context"
+	"encoding/json"
+)
 
 type Names struct {
 	Name     string    `json:"name"`
@@ -19,11 +22,29 @@ type Generation struct {
 	PokemonSpecies []NamedLink `json:"pokemon_species"`
 	Types          []NamedLink `json:"types"`
 	VersionGroups  []NamedLink `json:"version_groups"`
+
+	// Extra holds unknown fields, see `Config.KeepUnknownFields`.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// MarshalJSON encodes the Generation including any unknown fields in `Extra`.
+func (g Generation) MarshalJSON() ([]byte, error) {
+	type plain Generation
+	return marshalWithExtra(plain(g), g.Extra)
 }
 
 // GetGeneration returns a single Generation from the API.
 //
 //	gen1, err := sdk.GetGeneration(ctx, "generation-i")
-func (s *SDK) GetGeneration(ctx context.Context, name string) (*Generation, error) {
-	return Follow[Generation](ctx, s, s.baseURL+"/api/v2/generation/"+name)
+func (s *SDK) GetGeneration(ctx context.Context, name string, opts ...CallOption) (*Generation, error) {
+	value, _, err := s.GetGenerationWithResponse(ctx, name, opts...)
+	return value, err
+}
+
+// GetGenerationWithResponse is like `GetGeneration` but also returns metadata about the
+// response. See `FollowWithResponse`.
+//
+//	gen1, resp, err := sdk.GetGenerationWithResponse(ctx, "generation-i")
+func (s *SDK) GetGenerationWithResponse(ctx context.Context, name string, opts ...CallOption) (*Generation, *Response, error) {
+	return FollowWithResponse[Generation](ctx, s, s.baseURL+"/api/v2/generation/"+name, opts...)
 }
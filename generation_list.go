@@ -10,9 +10,6 @@ package pokesdk
 //	  }
 //	  fmt.Printf("Generation: %s\n", result.Value.Name)
 //	}
-func (s *SDK) ListGenerations() *Paginator[NamedLink] {
-	return &Paginator[NamedLink]{
-		sdk: s,
-		url: s.baseURL + "/api/v2/generation",
-	}
+func (s *SDK) ListGenerations(opts ...CallOption) *Paginator[NamedLink] {
+	return newPaginator[NamedLink](s, s.baseURL+"/api/v2/generation", opts)
 }
@@ -0,0 +1,129 @@
+// Command genenums generates typed constants for well-known API resource
+// names, such as stats and types, by listing them from the Pokemon API.
+//
+//	go run ./internal/genenums -o enums_gen.go
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"go/format"
+	"log"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/danielgtaylor/pokesdk"
+)
+
+// enum describes a single generated enum type and where to list its values.
+type enum struct {
+	Type     string
+	Prefix   string
+	Resource string
+	Doc      string
+	Values   []value
+}
+
+type value struct {
+	Const string
+	Name  string
+}
+
+var enums = []*enum{
+	{Type: "StatName", Prefix: "Stat", Resource: "stat", Doc: "a stat, e.g. `StatHP`"},
+	{Type: "TypeName", Prefix: "Type", Resource: "type", Doc: "an elemental type, e.g. `TypeElectric`"},
+	{Type: "LearnMethodName", Prefix: "LearnMethod", Resource: "move-learn-method", Doc: "a move learn method, e.g. `LearnMethodLevelUp`"},
+	{Type: "VersionName", Prefix: "Version", Resource: "version", Doc: "a game version, e.g. `VersionRed`"},
+}
+
+// initialisms are name parts which should be fully upper-cased.
+var initialisms = map[string]string{
+	"hp": "HP",
+	"xd": "XD",
+}
+
+func constName(prefix, name string) string {
+	var b strings.Builder
+	b.WriteString(prefix)
+	for _, part := range strings.Split(name, "-") {
+		if i, ok := initialisms[part]; ok {
+			b.WriteString(i)
+			continue
+		}
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+var tmpl = template.Must(template.New("enums").Parse(`// Code generated by genenums; DO NOT EDIT.
+
+package pokesdk
+{{range .}}{{$e := .}}
+// {{.Type}} is the name of {{.Doc}}.
+// Unknown names from newer API versions are preserved when decoding.
+type {{.Type}} string
+
+const (
+{{- range .Values}}
+	{{.Const}} {{$e.Type}} = "{{.Name}}"
+{{- end}}
+)
+
+// {{.Type}}s lists all known {{.Type}} values.
+var {{.Type}}s = []{{.Type}}{
+{{- range .Values}}
+	{{.Const}},
+{{- end}}
+}
+
+// Known returns whether the name is one of the generated constants.
+func (n {{.Type}}) Known() bool {
+	switch n {
+	case {{range $i, $v := .Values}}{{if $i}}, {{end}}{{$v.Const}}{{end}}:
+		return true
+	}
+	return false
+}
+
+// {{.Prefix}}Link is a link to {{.Doc}}.
+type {{.Prefix}}Link struct {
+	Name {{.Type}} ` + "`json:\"name\"`" + `
+	URL  string ` + "`json:\"url\"`" + `
+}
+{{end}}`))
+
+func main() {
+	baseURL := flag.String("base-url", "https://pokeapi.co", "API base URL")
+	output := flag.String("o", "enums_gen.go", "output file")
+	flag.Parse()
+
+	ctx := context.Background()
+	sdk := pokesdk.New(pokesdk.Config{BaseURL: *baseURL})
+
+	for _, e := range enums {
+		page, err := pokesdk.Follow[pokesdk.Page[pokesdk.NamedLink]](ctx, sdk, *baseURL+"/api/v2/"+e.Resource+"?limit=10000")
+		if err != nil {
+			log.Fatalf("failed to list %s: %v", e.Resource, err)
+		}
+		for _, link := range page.Results {
+			e.Values = append(e.Values, value{Const: constName(e.Prefix, link.Name), Name: link.Name})
+		}
+	}
+
+	// Templates are easier to read with generous whitespace, so let gofmt
+	// take care of the final layout.
+	buf := &bytes.Buffer{}
+	if err := tmpl.Execute(buf, enums); err != nil {
+		log.Fatalf("failed to render: %v", err)
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("failed to format: %v", err)
+	}
+
+	if err := os.WriteFile(*output, src, 0o644); err != nil {
+		log.Fatalf("failed to write %s: %v", *output, err)
+	}
+}
@@ -0,0 +1,137 @@
+package pokesdk
+
+import (
+	"context"
+	"strings"
+)
+
+// DefaultLanguage is the language used as a last resort when none of the
+// preferred languages are available for a piece of localized text.
+var DefaultLanguage = "en"
+
+// FlavorText is a localized piece of flavor text, such as a Pokedex entry,
+// optionally tied to the game version it appeared in.
+type FlavorText struct {
+	FlavorText string    `json:"flavor_text"`
+	Language   NamedLink `json:"language"`
+	Version    NamedLink `json:"version"`
+}
+
+// Genus is a localized genus, e.g. "Mouse Pokémon".
+type Genus struct {
+	Genus    string    `json:"genus"`
+	Language NamedLink `json:"language"`
+}
+
+// Localized is implemented by any API structure that holds a piece of text in
+// a single language, such as `Names`, `FlavorText` and `Genus`.
+type Localized interface {
+	LanguageName() string
+	LocalizedText() string
+}
+
+// LanguageName returns the name of the language, e.g. `de`.
+func (n Names) LanguageName() string { return n.Language.Name }
+
+// LocalizedText returns the name in the entry's language.
+func (n Names) LocalizedText() string { return n.Name }
+
+// LanguageName returns the name of the language, e.g. `de`.
+func (f FlavorText) LanguageName() string { return f.Language.Name }
+
+// LocalizedText returns the flavor text in the entry's language.
+func (f FlavorText) LocalizedText() string { return f.FlavorText }
+
+// LanguageName returns the name of the language, e.g. `de`.
+func (g Genus) LanguageName() string { return g.Language.Name }
+
+// LocalizedText returns the genus in the entry's language.
+func (g Genus) LocalizedText() string { return g.Genus }
+
+// FallbackChain expands a list of preferred languages into the full order in
+// which they should be tried. Each language is followed by its parents, and
+// the `DefaultLanguage` is always tried last.
+//
+//	FallbackChain("ja-Hrkt") // => ["ja-Hrkt", "ja", "en"]
+func FallbackChain(langs ...string) []string {
+	chain := make([]string, 0, len(langs)*2+1)
+	seen := map[string]bool{}
+	add := func(lang string) {
+		if lang != "" && !seen[lang] {
+			seen[lang] = true
+			chain = append(chain, lang)
+		}
+	}
+
+	for _, lang := range langs {
+		add(lang)
+		for i := strings.LastIndexByte(lang, '-'); i > 0; i = strings.LastIndexByte(lang, '-') {
+			lang = lang[:i]
+			add(lang)
+		}
+	}
+	add(DefaultLanguage)
+
+	return chain
+}
+
+// Localize returns the first entry matching the fallback chain of the given
+// preferred languages. If no entry matches, the returned boolean is false.
+//
+//	entry, ok := Localize(species.FlavorTextEntries, "de")
+func Localize[T Localized](entries []T, langs ...string) (T, bool) {
+	for _, lang := range FallbackChain(langs...) {
+		for _, entry := range entries {
+			if entry.LanguageName() == lang {
+				return entry, true
+			}
+		}
+	}
+
+	var zero T
+	return zero, false
+}
+
+// LocalizedName returns the name in the first available preferred language,
+// or an empty string if no suitable name exists.
+//
+//	name := LocalizedName(gen.Names, "de")
+func LocalizedName(names []Names, langs ...string) string {
+	entry, _ := Localize(names, langs...)
+	return entry.Name
+}
+
+// LocalizedFlavorText returns the flavor text in the first available preferred
+// language, or an empty string if no suitable text exists.
+func LocalizedFlavorText(entries []FlavorText, langs ...string) string {
+	entry, _ := Localize(entries, langs...)
+	return entry.FlavorText
+}
+
+// LocalizedGenus returns the genus in the first available preferred language,
+// or an empty string if no suitable genus exists.
+func LocalizedGenus(genera []Genus, langs ...string) string {
+	entry, _ := Localize(genera, langs...)
+	return entry.Genus
+}
+
+type languagesKey struct{}
+
+// WithLanguages returns a new context with the given language preferences,
+// which take precedence over the languages set in the SDK's `Config`.
+//
+//	ctx = pokesdk.WithLanguages(ctx, "ja-Hrkt", "ja")
+func WithLanguages(ctx context.Context, langs ...string) context.Context {
+	return context.WithValue(ctx, languagesKey{}, langs)
+}
+
+// Languages returns the full fallback chain of preferred languages for the
+// given context, taking into account both the context and the `Config`.
+//
+//	name := pokesdk.LocalizedName(gen.Names, sdk.Languages(ctx)...)
+func (s *SDK) Languages(ctx context.Context) []string {
+	if langs, ok := ctx.Value(languagesKey{}).([]string); ok && len(langs) > 0 {
+		return FallbackChain(langs...)
+	}
+	return FallbackChain(s.languages...)
+}
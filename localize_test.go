@@ -0,0 +1,81 @@
+package pokesdk_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/danielgtaylor/pokesdk"
+)
+
+var genNames = []pokesdk.Names{
+	{Name: "Generation I", Language: pokesdk.NamedLink{Name: "en"}},
+	{Name: "Generation I", Language: pokesdk.NamedLink{Name: "de"}},
+	{Name: "第一世代", Language: pokesdk.NamedLink{Name: "ja"}},
+	{Name: "Génération I", Language: pokesdk.NamedLink{Name: "fr"}},
+}
+
+func TestFallbackChain(t *testing.T) {
+	chain := pokesdk.FallbackChain("ja-Hrkt", "fr")
+	if !reflect.DeepEqual(chain, []string{"ja-Hrkt", "ja", "fr", "en"}) {
+		t.Errorf("unexpected chain: %v", chain)
+	}
+
+	chain = pokesdk.FallbackChain()
+	if !reflect.DeepEqual(chain, []string{"en"}) {
+		t.Errorf("unexpected default chain: %v", chain)
+	}
+}
+
+func TestLocalizedName(t *testing.T) {
+	if name := pokesdk.LocalizedName(genNames, "fr"); name != "Génération I" {
+		t.Errorf("expected french name, got %s", name)
+	}
+
+	if name := pokesdk.LocalizedName(genNames, "ja-Hrkt"); name != "第一世代" {
+		t.Errorf("expected japanese fallback name, got %s", name)
+	}
+
+	if name := pokesdk.LocalizedName(genNames, "ko"); name != "Generation I" {
+		t.Errorf("expected english fallback name, got %s", name)
+	}
+
+	if name := pokesdk.LocalizedName(genNames[1:2], "ko"); name != "" {
+		t.Errorf("expected no name, got %s", name)
+	}
+}
+
+func TestLocalizedText(t *testing.T) {
+	flavor := []pokesdk.FlavorText{
+		{FlavorText: "Wenn sich mehrere...", Language: pokesdk.NamedLink{Name: "de"}},
+		{FlavorText: "When several of...", Language: pokesdk.NamedLink{Name: "en"}},
+	}
+	if text := pokesdk.LocalizedFlavorText(flavor, "de"); text != "Wenn sich mehrere..." {
+		t.Errorf("unexpected flavor text: %s", text)
+	}
+
+	genera := []pokesdk.Genus{
+		{Genus: "Mouse Pokémon", Language: pokesdk.NamedLink{Name: "en"}},
+	}
+	if genus := pokesdk.LocalizedGenus(genera, "de"); genus != "Mouse Pokémon" {
+		t.Errorf("unexpected genus: %s", genus)
+	}
+
+	if _, ok := pokesdk.Localize([]pokesdk.Genus{}, "de"); ok {
+		t.Errorf("expected no match")
+	}
+}
+
+func TestLanguages(t *testing.T) {
+	ctx := context.Background()
+	sdk := pokesdk.New(pokesdk.Config{Languages: []string{"de"}})
+
+	if langs := sdk.Languages(ctx); !reflect.DeepEqual(langs, []string{"de", "en"}) {
+		t.Errorf("unexpected config languages: %v", langs)
+	}
+
+	ctx = pokesdk.WithLanguages(ctx, "ja-Hrkt")
+	if langs := sdk.Languages(ctx); !reflect.DeepEqual(langs, []string{"ja-Hrkt", "ja", "en"}) {
+		t.Errorf("unexpected context languages: %v", langs)
+	}
+}
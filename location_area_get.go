@@ -0,0 +1,76 @@
+package pokesdk
+
+import (
+	"context"
+	"encoding/json"
+)
+
+type EncounterVersionDetails struct {
+	Rate    int         `json:"rate"`
+	Version VersionLink `json:"version"`
+}
+
+type EncounterMethodRate struct {
+	EncounterMethod NamedLink                 `json:"encounter_method"`
+	VersionDetails  []EncounterVersionDetails `json:"version_details"`
+}
+
+type PokemonEncounter struct {
+	Pokemon        NamedLink                `json:"pokemon"`
+	VersionDetails []VersionEncounterDetail `json:"version_details"`
+}
+
+// LocationArea is a section of a location, such as a floor of a building or
+// a patch of grass, and the Pokemon that can be encountered there.
+type LocationArea struct {
+	ID                   int                   `json:"id"`
+	Name                 string                `json:"name"`
+	GameIndex            int                   `json:"game_index"`
+	EncounterMethodRates []EncounterMethodRate `json:"encounter_method_rates"`
+	Location             NamedLink             `json:"location"`
+	Names                []Names               `json:"names"`
+	PokemonEncounters    []PokemonEncounter    `json:"pokemon_encounters"`
+
+	// Extra holds unknown fields, see `Config.KeepUnknownFields`.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// MarshalJSON encodes the LocationArea including any unknown fields in `Extra`.
+func (a LocationArea) MarshalJSON() ([]byte, error) {
+	type plain LocationArea
+	return marshalWithExtra(plain(a), a.Extra)
+}
+
+// GetLocationArea returns a single LocationArea from the API.
+//
+//	area, err := sdk.GetLocationArea(ctx, "viridian-forest-area")
+func (s *SDK) GetLocationArea(ctx context.Context, name string, opts ...CallOption) (*LocationArea, error) {
+	value, _, err := s.GetLocationAreaWithResponse(ctx, name, opts...)
+	return value, err
+}
+
+// GetLocationAreaWithResponse is like `GetLocationArea` but also returns metadata about the
+// response. See `FollowWithResponse`.
+//
+//	area, resp, err := sdk.GetLocationAreaWithResponse(ctx, "viridian-forest-area")
+func (s *SDK) GetLocationAreaWithResponse(ctx context.Context, name string, opts ...CallOption) (*LocationArea, *Response, error) {
+	return FollowWithResponse[LocationArea](ctx, s, s.baseURL+"/api/v2/location-area/"+name, opts...)
+}
+
+// PokemonInVersion filters the area's encounters down to the given game
+// version, answering "what can be caught here in version X?"
+func (a *LocationArea) PokemonInVersion(version VersionName) []PokemonEncounter {
+	filtered := []PokemonEncounter{}
+	for _, e := range a.PokemonEncounters {
+		for _, d := range e.VersionDetails {
+			if d.Version.Name == version {
+				filtered = append(filtered, PokemonEncounter{
+					Pokemon:        e.Pokemon,
+					VersionDetails: []VersionEncounterDetail{d},
+				})
+				break
+			}
+		}
+	}
+	return filtered
+}
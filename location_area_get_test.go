@@ -0,0 +1,75 @@
+package pokesdk_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/danielgtaylor/pokesdk"
+)
+
+const viridianForest = `{
+	"id": 321,
+	"name": "viridian-forest-area",
+	"location": {"name": "viridian-forest", "url": ""},
+	"pokemon_encounters": [
+		{
+			"pokemon": {"name": "caterpie", "url": ""},
+			"version_details": [
+				{"version": {"name": "red"}, "max_chance": 50, "encounter_details": [{"min_level": 3, "max_level": 5, "chance": 50, "method": {"name": "walk"}, "condition_values": []}]},
+				{"version": {"name": "blue"}, "max_chance": 5, "encounter_details": []}
+			]
+		},
+		{
+			"pokemon": {"name": "pikachu", "url": ""},
+			"version_details": [
+				{"version": {"name": "yellow"}, "max_chance": 5, "encounter_details": []}
+			]
+		}
+	]
+}`
+
+func TestGetLocationArea(t *testing.T) {
+	ctx := context.Background()
+
+	transport := &mockTransport{}
+	transport.Expect("https://pokeapi.co/api/v2/location-area/viridian-forest-area", http.StatusOK, viridianForest)
+
+	sdk := pokesdk.New(pokesdk.Config{
+		Client: &http.Client{Transport: transport},
+	})
+
+	area, err := sdk.GetLocationArea(ctx, "viridian-forest-area")
+	if err != nil {
+		t.Fatalf("failed to get location area: %v", err)
+	}
+
+	if area.Location.Name != "viridian-forest" || len(area.PokemonEncounters) != 2 {
+		t.Errorf("unexpected location area: %+v", area)
+	}
+}
+
+func TestCatchableIn(t *testing.T) {
+	ctx := context.Background()
+
+	transport := &mockTransport{}
+	transport.Expect("https://pokeapi.co/api/v2/location-area/viridian-forest-area", http.StatusOK, viridianForest)
+
+	sdk := pokesdk.New(pokesdk.Config{
+		Client: &http.Client{Transport: transport},
+	})
+
+	found, err := sdk.CatchableIn(ctx, "viridian-forest-area", pokesdk.VersionRed)
+	if err != nil {
+		t.Fatalf("failed to get catchable pokemon: %v", err)
+	}
+
+	if len(found) != 1 || found[0].Pokemon.Name != "caterpie" {
+		t.Fatalf("expected only caterpie, got %+v", found)
+	}
+
+	detail := found[0].VersionDetails[0].EncounterDetails[0]
+	if detail.MinLevel != 3 || detail.MaxLevel != 5 || detail.Chance != 50 || detail.Method.Name != "walk" {
+		t.Errorf("unexpected encounter details: %+v", detail)
+	}
+}
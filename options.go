@@ -0,0 +1,154 @@
+package pokesdk
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// CallOption customizes a single call, such as `GetPokemon` or a paginator's
+// requests.
+//
+//	pika, err := sdk.GetPokemon(ctx, "pikachu",
+//		pokesdk.WithTimeout(2*time.Second),
+//		pokesdk.WithHeader("X-Request-ID", id),
+//	)
+type CallOption func(*callOptions)
+
+type callOptions struct {
+	timeout  time.Duration
+	header   http.Header
+	query    url.Values
+	noCache  bool
+	retries  *int
+	priority int
+}
+
+// WithTimeout limits how long the call may take, including retries. For
+// paginators it applies to each page.
+func WithTimeout(timeout time.Duration) CallOption {
+	return func(o *callOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithHeader sets a header on the call's requests, replacing any value the
+// SDK would otherwise send.
+func WithHeader(key, value string) CallOption {
+	return func(o *callOptions) {
+		o.header.Set(key, value)
+	}
+}
+
+// WithQuery sets a query parameter on the call's requests, replacing any
+// existing value. For paginators it applies to every page, e.g. to set the
+// page size with `WithQuery("limit", "100")`.
+func WithQuery(key, value string) CallOption {
+	return func(o *callOptions) {
+		o.query.Set(key, value)
+	}
+}
+
+// NoCache asks caches between the SDK and the API to revalidate, by sending
+// `Cache-Control: no-cache`, and skips sharing results with concurrent calls
+// when `Config.Coalesce` is enabled. Use it to force a fresh response.
+func NoCache() CallOption {
+	return func(o *callOptions) {
+		o.noCache = true
+	}
+}
+
+// WithRetries overrides `Config.Retries` for the call. Use zero to disable
+// retries.
+func WithRetries(retries int) CallOption {
+	return func(o *callOptions) {
+		o.retries = &retries
+	}
+}
+
+// WithPriority sets the priority of the call's requests while they wait for
+// `Config.MaxConcurrentRequests`. Higher priorities start first, and the
+// default is zero, e.g. use a negative priority for a background crawl so
+// that other lookups overtake it.
+func WithPriority(priority int) CallOption {
+	return func(o *callOptions) {
+		o.priority = priority
+	}
+}
+
+type callOptionsKey struct{}
+
+// withCallOptions returns a context carrying the options, combined with any
+// options already on the context. The returned cancel function must be
+// called once the call is done.
+func withCallOptions(ctx context.Context, opts []CallOption) (context.Context, context.CancelFunc) {
+	if len(opts) == 0 {
+		return ctx, func() {}
+	}
+
+	o := &callOptions{header: http.Header{}, query: url.Values{}}
+	if prev := callOptionsFrom(ctx); prev != nil {
+		*o = *prev
+		o.header = prev.header.Clone()
+		o.query = url.Values{}
+		for k, v := range prev.query {
+			o.query[k] = v
+		}
+	}
+
+	// An earlier timeout already applies via the context, but is still
+	// recorded so the call is not shared.
+	inherited := o.timeout
+	o.timeout = 0
+	for _, opt := range opts {
+		opt(o)
+	}
+	timeout := o.timeout
+	if timeout == 0 {
+		o.timeout = inherited
+	}
+
+	ctx = context.WithValue(ctx, callOptionsKey{}, o)
+	if timeout > 0 {
+		return context.WithTimeout(ctx, timeout)
+	}
+	return ctx, func() {}
+}
+
+// callOptionsFrom returns the call options from the context, if any.
+func callOptionsFrom(ctx context.Context) *callOptions {
+	o, _ := ctx.Value(callOptionsKey{}).(*callOptions)
+	return o
+}
+
+// shareable returns whether the call may share its result with others. Calls
+// with their own timeout, retries or priority are not shared, since a shared
+// call runs with the first caller's settings.
+func (o *callOptions) shareable() bool {
+	return o == nil || (!o.noCache && len(o.header) == 0 && len(o.query) == 0 && o.timeout == 0 && o.retries == nil && o.priority == 0)
+}
+
+// apply adds the options to a request.
+func (o *callOptions) apply(req *http.Request) {
+	if o == nil {
+		return
+	}
+
+	if len(o.query) > 0 {
+		q := req.URL.Query()
+		for k, v := range o.query {
+			q[k] = v
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	if o.noCache {
+		req.Header.Set("Cache-Control", "no-cache")
+		req.Header.Set("Pragma", "no-cache")
+	}
+
+	for k, v := range o.header {
+		req.Header[k] = v
+	}
+}
@@ -0,0 +1,258 @@
+package pokesdk_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"path"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/pokesdk"
+)
+
+func TestCallOptions(t *testing.T) {
+	ctx := context.Background()
+
+	transport := &mockTransport{}
+	transport.Expect("https://pokeapi.co/api/v2/pokemon/pikachu?lang=de", http.StatusOK, `{"name":"pikachu"}`)
+
+	sdk := pokesdk.New(pokesdk.Config{
+		Client: &http.Client{Transport: transport},
+	})
+
+	_, err := sdk.GetPokemon(ctx, "pikachu",
+		pokesdk.WithHeader("X-Request-ID", "abc123"),
+		pokesdk.WithHeader("Accept", "application/json"),
+		pokesdk.WithQuery("lang", "de"),
+		pokesdk.NoCache(),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	req := transport.requests[0]
+	for header, expected := range map[string]string{
+		"X-Request-ID":  "abc123",
+		"Accept":        "application/json",
+		"Cache-Control": "no-cache",
+	} {
+		if v := req.Header.Get(header); v != expected {
+			t.Errorf("expected %s header %q, got %q", header, expected, v)
+		}
+	}
+}
+
+func TestCallOptionsPaginator(t *testing.T) {
+	ctx := context.Background()
+
+	transport := &mockTransport{}
+	transport.Expect("https://pokeapi.co/api/v2/pokemon?limit=1", http.StatusOK, `{
+		"count": 2,
+		"next": "https://pokeapi.co/api/v2/pokemon?offset=1&limit=20",
+		"results": [{"name": "bulbasaur", "url": "https://pokeapi.co/api/v2/pokemon/1/"}]
+	}`)
+	transport.Expect("https://pokeapi.co/api/v2/pokemon?limit=1&offset=1", http.StatusOK, `{
+		"count": 2,
+		"results": [{"name": "ivysaur", "url": "https://pokeapi.co/api/v2/pokemon/2/"}]
+	}`)
+
+	sdk := pokesdk.New(pokesdk.Config{
+		Client: &http.Client{Transport: transport},
+	})
+
+	count := 0
+	for result := range sdk.ListPokemon(pokesdk.WithQuery("limit", "1")).All(ctx) {
+		if result.Error != nil {
+			t.Fatalf("unexpected error: %v", result.Error)
+		}
+		count++
+	}
+
+	if count != 2 {
+		t.Errorf("expected 2 results, got %d", count)
+	}
+}
+
+func TestCallOptionsTimeout(t *testing.T) {
+	ctx := context.Background()
+
+	sdk := pokesdk.New(pokesdk.Config{
+		Client: &http.Client{Transport: &blockingTransport{release: make(chan struct{})}},
+	})
+
+	_, err := pokesdk.Follow[pokesdk.Pokemon](ctx, sdk, "https://pokeapi.co/api/v2/pokemon/pikachu",
+		pokesdk.WithTimeout(10*time.Millisecond),
+	)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestRetries(t *testing.T) {
+	ctx := context.Background()
+
+	transport := &mockTransport{}
+	transport.Expect("https://pokeapi.co/api/v2/pokemon/pikachu", http.StatusServiceUnavailable, ``)
+	transport.Expect("https://pokeapi.co/api/v2/pokemon/pikachu", http.StatusTooManyRequests, ``)
+	transport.Expect("https://pokeapi.co/api/v2/pokemon/pikachu", http.StatusOK, `{"name":"pikachu"}`)
+	transport.Expect("https://pokeapi.co/api/v2/pokemon/pikachu", http.StatusServiceUnavailable, ``)
+
+	sdk := pokesdk.New(pokesdk.Config{
+		Client:       &http.Client{Transport: transport},
+		Retries:      2,
+		RetryBackoff: time.Millisecond,
+	})
+
+	if _, err := sdk.GetPokemon(ctx, "pikachu"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(transport.requests) != 3 {
+		t.Errorf("expected 3 requests, got %d", len(transport.requests))
+	}
+
+	// Retries can be disabled per call.
+	if _, err := sdk.GetPokemon(ctx, "pikachu", pokesdk.WithRetries(0)); !errors.Is(err, pokesdk.APIError) {
+		t.Errorf("expected API error, got %v", err)
+	}
+
+	if len(transport.requests) != 4 {
+		t.Errorf("expected 4 requests, got %d", len(transport.requests))
+	}
+}
+
+func TestRetriesCircuitOpen(t *testing.T) {
+	ctx := context.Background()
+
+	transport := &mockTransport{}
+
+	sdk := pokesdk.New(pokesdk.Config{
+		Client:         &http.Client{Transport: transport},
+		Retries:        5,
+		RetryBackoff:   time.Millisecond,
+		CircuitBreaker: &pokesdk.CircuitBreaker{FailureThreshold: 2},
+	})
+
+	if _, err := sdk.GetPokemon(ctx, "pikachu"); !errors.Is(err, pokesdk.ErrCircuitOpen) {
+		t.Errorf("expected circuit open error, got %v", err)
+	}
+
+	if len(transport.requests) != 2 {
+		t.Errorf("expected retries to stop once the circuit opens, got %d requests", len(transport.requests))
+	}
+}
+
+// orderedTransport holds requests for `block` until it is closed, and
+// records the order requests were sent in.
+type orderedTransport struct {
+	mu      sync.Mutex
+	order   []string
+	started chan string
+	block   chan struct{}
+}
+
+func (t *orderedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	name := path.Base(req.URL.Path)
+	t.mu.Lock()
+	t.order = append(t.order, name)
+	t.mu.Unlock()
+	t.started <- name
+
+	if name == "block" {
+		<-t.block
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(`{"name":"` + name + `"}`)),
+	}, nil
+}
+
+// waitForRequests waits until the given number of requests are waiting for a
+// slot.
+func waitForRequests(t *testing.T, sdk *pokesdk.SDK, n int) {
+	t.Helper()
+	for start := time.Now(); pokesdk.WaitingRequests(sdk) != n; time.Sleep(time.Millisecond) {
+		if time.Since(start) > 5*time.Second {
+			t.Fatalf("expected %d waiting requests, got %d", n, pokesdk.WaitingRequests(sdk))
+		}
+	}
+}
+
+func TestWithPriority(t *testing.T) {
+	ctx := context.Background()
+
+	transport := &orderedTransport{started: make(chan string, 10), block: make(chan struct{})}
+	sdk := pokesdk.New(pokesdk.Config{
+		Client:                &http.Client{Transport: transport},
+		MaxConcurrentRequests: 1,
+	})
+
+	wg := sync.WaitGroup{}
+	get := func(name string, opts ...pokesdk.CallOption) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := sdk.GetPokemon(ctx, name, opts...); err != nil {
+				t.Errorf("%s: unexpected error: %v", name, err)
+			}
+		}()
+	}
+
+	// The first request takes the only slot until it is unblocked.
+	get("block")
+	<-transport.started
+
+	get("crawl-1", pokesdk.WithPriority(-1))
+	waitForRequests(t, sdk, 1)
+	get("crawl-2", pokesdk.WithPriority(-1))
+	waitForRequests(t, sdk, 2)
+	get("default")
+	waitForRequests(t, sdk, 3)
+	get("urgent", pokesdk.WithPriority(10))
+	waitForRequests(t, sdk, 4)
+
+	close(transport.block)
+	wg.Wait()
+
+	expected := []string{"block", "urgent", "default", "crawl-1", "crawl-2"}
+	if !reflect.DeepEqual(transport.order, expected) {
+		t.Errorf("expected order %v, got %v", expected, transport.order)
+	}
+}
+
+func TestMaxConcurrentRequestsCanceled(t *testing.T) {
+	transport := &orderedTransport{started: make(chan string, 10), block: make(chan struct{})}
+	sdk := pokesdk.New(pokesdk.Config{
+		Client:                &http.Client{Transport: transport},
+		MaxConcurrentRequests: 1,
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		sdk.GetPokemon(context.Background(), "block")
+	}()
+	<-transport.started
+
+	// A canceled request gives up its place in the queue.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := sdk.GetPokemon(ctx, "waiting"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+	if n := pokesdk.WaitingRequests(sdk); n != 0 {
+		t.Errorf("expected no waiting requests, got %d", n)
+	}
+
+	// The slot is free again once the first response is closed.
+	close(transport.block)
+	<-done
+	if _, err := sdk.GetPokemon(context.Background(), "after"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
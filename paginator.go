@@ -2,10 +2,11 @@ package pokesdk
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
-	"net/http"
+	"errors"
+	"net/url"
+	"strconv"
 	"sync"
+	"time"
 )
 
 // DefaultPageBufferSize is the size of the channel holding page items. When
@@ -14,6 +15,9 @@ import (
 // items are more likely to already be available when requested.
 var DefaultPageBufferSize = 10
 
+// defaultPageLimit is the API's page size when no limit is given.
+const defaultPageLimit = 20
+
 // Page is a single page of results from the API. It may contain next/prev
 // links for pagination, and a total count of items.
 type Page[T any] struct {
@@ -23,44 +27,275 @@ type Page[T any] struct {
 	Results  []T    `json:"results"`
 }
 
+// PageErrorPolicy decides what a paginator does when fetching a page fails.
+// The zero value stops at the first error.
+//
+//	p := sdk.ListPokemon()
+//	p.ErrorPolicy = pokesdk.PageErrorPolicy{Retries: 3, Skip: true}
+type PageErrorPolicy struct {
+	// Retries is how many times a failed page is retried before giving up.
+	// The first retry waits `Backoff`, which doubles for each further retry.
+	Retries int
+
+	// Backoff defaults to `DefaultRetryBackoff`.
+	Backoff time.Duration
+
+	// Skip moves on to the following page after giving up on a page, instead
+	// of stopping. The error is still reported. This relies on the API's
+	// `offset` and `limit` query parameters to find the following page, and
+	// on the total count from an earlier page to know when to stop.
+	Skip bool
+}
+
+// PaginatorState is a checkpoint of a paginator's position which can be
+// serialized, e.g. as JSON, and later passed to `Paginator.Restore` to resume
+// iteration where it left off.
+type PaginatorState struct {
+	// URL is the next page to fetch. It is empty once iteration is done.
+	URL string `json:"url"`
+
+	// Skip is the number of items on that page which were already consumed.
+	Skip int `json:"skip,omitempty"`
+
+	// Index is the overall index of the next item.
+	Index int `json:"index"`
+
+	// Count is the total number of items from the last page, if known.
+	Count int `json:"count,omitempty"`
+
+	// Seen lists the keys of items already yielded by `All`, so duplicates
+	// are still detected after resuming. It is only recorded for the
+	// `DriftDedupe` and `DriftRestart` policies.
+	Seen []string `json:"seen,omitempty"`
+
+	// Restarts is how often `DriftRestart` has started over.
+	Restarts int `json:"restarts,omitempty"`
+}
+
+// Done returns whether there is nothing left to iterate.
+func (s PaginatorState) Done() bool {
+	return s.URL == ""
+}
+
 // Paginator is a helper for paginating through API results. It can be used to
 // manually iterate over pages or to get a channel of all results.
 type Paginator[T any] struct {
-	sdk *SDK
-	url string
+	// ErrorPolicy decides what happens when fetching a page fails.
+	ErrorPolicy PageErrorPolicy
+
+	// DriftPolicy decides what happens when `All` detects that the list
+	// changed while iterating.
+	DriftPolicy DriftPolicy
+
+	// OnDrift is called whenever `All` detects that the list changed. It is
+	// called from the goroutine fetching pages.
+	OnDrift func(Drift)
+
+	sdk   *SDK
+	start string
+	opts  []CallOption
+
+	mu       sync.Mutex
+	url      string
+	skip     int
+	index    int
+	count    int
+	seen     map[string]bool
+	seenKeys []string
+	restarts int
+}
+
+// newPaginator returns a paginator starting at the given URL.
+func newPaginator[T any](sdk *SDK, url string, opts []CallOption) *Paginator[T] {
+	return &Paginator[T]{sdk: sdk, start: url, url: url, opts: opts}
+}
+
+// State returns a checkpoint of the paginator's position. While iterating
+// with `All`, items buffered in the channel count as consumed, so use
+// `IteratorResult.State` for a checkpoint of exactly the items processed.
+func (p *Paginator[T]) State() PaginatorState {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.state()
+}
+
+// state returns the current checkpoint. The lock must be held.
+func (p *Paginator[T]) state() PaginatorState {
+	state := PaginatorState{URL: p.url, Skip: p.skip, Index: p.index, Count: p.count, Restarts: p.restarts}
+	if p.DriftPolicy != DriftWarn && len(p.seenKeys) > 0 {
+		// Keys are only ever appended, so the checkpoint can share them.
+		state.Seen = p.seenKeys[:len(p.seenKeys):len(p.seenKeys)]
+	}
+	return state
+}
+
+// Restore sets the paginator's position from a checkpoint returned by
+// `State` or `IteratorResult.State`.
+//
+//	p := sdk.ListPokemon()
+//	p.Restore(saved)
+//	for result := range p.All(ctx) {
+//		// ...
+//	}
+func (p *Paginator[T]) Restore(state PaginatorState) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.url = state.URL
+	p.skip = state.Skip
+	p.index = state.Index
+	p.count = state.Count
+	p.restarts = state.Restarts
+	p.seenKeys = append([]string(nil), state.Seen...)
+	p.seen = make(map[string]bool, len(state.Seen))
+	for _, key := range state.Seen {
+		p.seen[key] = true
+	}
+}
+
+// markSeen records that an item was yielded and returns whether it was seen
+// before.
+func (p *Paginator[T]) markSeen(key string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.seen[key] {
+		return true
+	}
+	if p.seen == nil {
+		p.seen = map[string]bool{}
+	}
+	p.seen[key] = true
+	p.seenKeys = append(p.seenKeys, key)
+	return false
+}
+
+// restart starts over from the first page if the drift policy allows it.
+// Indexes continue from where the previous pass stopped so they stay unique.
+func (p *Paginator[T]) restart() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.DriftPolicy != DriftRestart || p.restarts >= maxDriftRestarts {
+		return false
+	}
+	p.restarts++
+	p.url, p.skip, p.count = p.start, 0, 0
+	return true
 }
 
 // Next fetches the next page of results from the API. If there are no more
-// pages, the `Next` field of the returned page will be empty.
+// pages, the `Next` field of the returned page will be empty. Call options
+// passed when creating the paginator apply to each page.
+//
+// Failed pages are retried according to the `ErrorPolicy`. When it skips a
+// page the error is still returned, and calling `Next` again fetches the
+// following page.
 func (p *Paginator[T]) Next(ctx context.Context) (*Page[T], error) {
-	resp, err := p.sdk.Request(ctx, http.MethodGet, p.url, nil)
+	p.mu.Lock()
+	u, skip := p.url, p.skip
+	p.mu.Unlock()
+
+	page, err := p.fetch(ctx, u)
 	if err != nil {
+		if p.ErrorPolicy.Skip && ctx.Err() == nil && !errors.Is(err, ErrCircuitOpen) {
+			p.mu.Lock()
+			if following, offset, limit, ok := followingPage(u); ok && p.count > 0 {
+				if offset >= p.count {
+					following = ""
+				}
+				// The skipped items still count towards the index, so
+				// later items keep their place in the list.
+				p.url, p.skip = following, 0
+				p.index += limit - skip
+			}
+			p.mu.Unlock()
+		}
 		return nil, err
 	}
 
-	var page *Page[T]
-	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
+	// Drop items already consumed before a restore.
+	page.Results = page.Results[min(skip, len(page.Results)):]
 
-	p.url = page.Next
+	p.mu.Lock()
+	p.url, p.skip = page.Next, 0
+	p.index += len(page.Results)
+	p.count = page.Count
+	p.mu.Unlock()
 	return page, nil
 }
 
+// fetch gets a page, retrying according to the error policy.
+func (p *Paginator[T]) fetch(ctx context.Context, u string) (*Page[T], error) {
+	backoff := p.ErrorPolicy.Backoff
+	if backoff <= 0 {
+		backoff = DefaultRetryBackoff
+	}
+
+	for attempt := 0; ; attempt++ {
+		page, _, err := FollowWithResponse[Page[T]](ctx, p.sdk, u, p.opts...)
+		if err == nil || attempt >= p.ErrorPolicy.Retries || ctx.Err() != nil ||
+			errors.Is(err, ErrCircuitOpen) || errors.Is(err, DisallowedURLError) {
+			return page, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-time.After(backoff << attempt):
+		}
+	}
+}
+
+// followingPage returns the URL and offset of the page after the given one,
+// and the given page's limit, based on its `offset` and `limit` query
+// parameters.
+func followingPage(u string) (string, int, int, bool) {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return "", 0, 0, false
+	}
+
+	query := parsed.Query()
+	offset, limit := 0, defaultPageLimit
+	if v := query.Get("offset"); v != "" {
+		if offset, err = strconv.Atoi(v); err != nil {
+			return "", 0, 0, false
+		}
+	}
+	if v := query.Get("limit"); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil || limit < 1 {
+			return "", 0, 0, false
+		}
+	}
+
+	offset += limit
+	query.Set("offset", strconv.Itoa(offset))
+	query.Set("limit", strconv.Itoa(limit))
+	parsed.RawQuery = query.Encode()
+	return parsed.String(), offset, limit, true
+}
+
 // IteratorResult is a single result from the paginator. It contains the page
 // the result was found on, the value itself, and any error that occurred. This
-// is used to send results oßver a channel so that errors can still be detected.
+// is used to send results over a channel so that errors can still be detected.
 type IteratorResult[T any] struct {
 	Page  *Page[T]
 	Index int
 	Value T
 	Error error
+
+	// Drift is set when the list changed while iterating, either because the
+	// count on this result's page differs from the previous page, because
+	// the value is a duplicate, or because `DriftRestart` started over before
+	// this result. See `Paginator.DriftPolicy`.
+	Drift bool
+
+	// State is a checkpoint for resuming iteration after this result.
+	State PaginatorState
 }
 
 // All returns a channel of all results from the paginator. This will fetch
 // pages in the background as needed and close the channel when there are no
 // more results. If an error occurs, the error will be sent on the channel and
-// the channel will be closed.
+// the channel will be closed, unless the `ErrorPolicy` skips the page.
 //
 //	for result := range paginator.All(ctx) {
 //		if result.Error != nil {
@@ -76,7 +311,7 @@ func (p *Paginator[T]) All(ctx context.Context) chan IteratorResult[T] {
 // AllWithCancel returns a channel of all results from the paginator. This will fetch
 // pages in the background as needed and close the channel when there are no
 // more results. If an error occurs, the error will be sent on the channel and
-// the channel will be closed.
+// the channel will be closed, unless the `ErrorPolicy` skips the page.
 //
 //	iter, cancel := paginator.All(ctx)
 //	for result := range iter {
@@ -102,25 +337,68 @@ func (p *Paginator[T]) AllWithCancel(ctx context.Context) (chan IteratorResult[T
 			mu.Unlock()
 		}()
 
-		index := 0
-		for p.url != "" {
+	pages:
+		for {
+			state := p.State()
+			if state.Done() {
+				return
+			}
+
 			page, err := p.Next(ctx)
 			if err != nil {
+				next := p.State()
 				select {
 				case <-done:
 					return
-				case ch <- IteratorResult[T]{Page: page, Index: index, Error: err}:
+				case ch <- IteratorResult[T]{Page: page, Index: state.Index, Error: err, State: next}:
 				}
-				return
+				if next.URL == state.URL {
+					// The page was not skipped, so stop.
+					return
+				}
+				continue
 			}
 
-			for _, v := range page.Results {
+			drift := state.Count > 0 && page.Count != state.Count
+			if drift {
+				p.reportDrift(Drift{Page: state.URL, PreviousCount: state.Count, Count: page.Count})
+				if p.restart() {
+					continue
+				}
+			}
+
+			// Everything after a restart comes from a list known to have
+			// changed.
+			drift = drift || state.Restarts > 0
+
+			for i, v := range page.Results {
+				duplicate := false
+				if key, ok := itemKey(v); ok && p.markSeen(key) {
+					if state.Restarts == 0 {
+						// Items seen again after a restart are expected.
+						duplicate = true
+						p.reportDrift(Drift{Page: state.URL, PreviousCount: page.Count, Count: page.Count, Duplicate: key})
+						if p.restart() {
+							continue pages
+						}
+					}
+					if p.DriftPolicy != DriftWarn {
+						continue
+					}
+				}
+
+				p.mu.Lock()
+				checkpoint := p.state()
+				p.mu.Unlock()
+				checkpoint.URL, checkpoint.Skip, checkpoint.Index, checkpoint.Count = state.URL, state.Skip+i+1, state.Index+i+1, page.Count
+				if i == len(page.Results)-1 {
+					checkpoint.URL, checkpoint.Skip = page.Next, 0
+				}
 				select {
 				case <-done:
 					return
-				case ch <- IteratorResult[T]{Page: page, Index: index, Value: v}:
+				case ch <- IteratorResult[T]{Page: page, Index: state.Index + i, Value: v, Drift: drift || duplicate, State: checkpoint}:
 				}
-				index++
 			}
 		}
 	}()
@@ -134,3 +412,9 @@ func (p *Paginator[T]) AllWithCancel(ctx context.Context) (chan IteratorResult[T
 		mu.Unlock()
 	}
 }
+
+func (p *Paginator[T]) reportDrift(drift Drift) {
+	if p.OnDrift != nil {
+		p.OnDrift(drift)
+	}
+}
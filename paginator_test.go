@@ -0,0 +1,181 @@
+package pokesdk_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/pokesdk"
+)
+
+func collectNames(t *testing.T, p *pokesdk.Paginator[pokesdk.NamedLink]) ([]string, []error) {
+	t.Helper()
+	names := []string{}
+	errs := []error{}
+	for result := range p.All(context.Background()) {
+		if result.Error != nil {
+			errs = append(errs, result.Error)
+			continue
+		}
+		names = append(names, result.Value.Name)
+	}
+	return names, errs
+}
+
+func TestPaginatorRetry(t *testing.T) {
+	transport := &mockTransport{}
+	transport.Expect("https://pokeapi.co/api/v2/pokemon", http.StatusOK, listResultPage1)
+	transport.Expect("https://pokeapi.co/api/v2/pokemon?offset=20&limit=20", http.StatusBadGateway, "")
+	transport.Expect("https://pokeapi.co/api/v2/pokemon?offset=20&limit=20", http.StatusOK, listResultPage2)
+
+	sdk := pokesdk.New(pokesdk.Config{
+		Client: &http.Client{Transport: transport},
+	})
+
+	p := sdk.ListPokemon()
+	p.ErrorPolicy = pokesdk.PageErrorPolicy{Retries: 1, Backoff: time.Millisecond}
+
+	names, errs := collectNames(t, p)
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	if len(names) != 6 {
+		t.Errorf("expected 6 results, got %v", names)
+	}
+}
+
+func TestPaginatorSkip(t *testing.T) {
+	transport := &mockTransport{}
+	transport.Expect("https://pokeapi.co/api/v2/pokemon", http.StatusOK, `{
+		"count": 4,
+		"next": "https://pokeapi.co/api/v2/pokemon?offset=1&limit=1",
+		"results": [{"name": "bulbasaur", "url": "https://pokeapi.co/api/v2/pokemon/1/"}]
+	}`)
+	transport.Expect("https://pokeapi.co/api/v2/pokemon?offset=1&limit=1", http.StatusInternalServerError, "")
+	transport.Expect("https://pokeapi.co/api/v2/pokemon?limit=1&offset=2", http.StatusOK, `{
+		"count": 4,
+		"next": "https://pokeapi.co/api/v2/pokemon?offset=3&limit=1",
+		"results": [{"name": "venusaur", "url": "https://pokeapi.co/api/v2/pokemon/3/"}]
+	}`)
+	// This page fails too, but it is the last one so iteration stops.
+	transport.Expect("https://pokeapi.co/api/v2/pokemon?offset=3&limit=1", http.StatusInternalServerError, "")
+
+	sdk := pokesdk.New(pokesdk.Config{
+		Client: &http.Client{Transport: transport},
+	})
+
+	p := sdk.ListPokemon()
+	p.ErrorPolicy = pokesdk.PageErrorPolicy{Skip: true}
+
+	names := []string{}
+	indexes := []int{}
+	errs := []error{}
+	for result := range p.All(context.Background()) {
+		if result.Error != nil {
+			errs = append(errs, result.Error)
+			continue
+		}
+		names = append(names, result.Value.Name)
+		indexes = append(indexes, result.Index)
+	}
+
+	if len(errs) != 2 {
+		t.Errorf("expected 2 errors, got %v", errs)
+	}
+
+	if !reflect.DeepEqual(names, []string{"bulbasaur", "venusaur"}) {
+		t.Errorf("unexpected results: %v", names)
+	}
+
+	// Skipped items still count, so each item keeps its place in the list.
+	if !reflect.DeepEqual(indexes, []int{0, 2}) {
+		t.Errorf("unexpected indexes: %v", indexes)
+	}
+
+	state := p.State()
+	if !state.Done() {
+		t.Errorf("expected paginator to be done, got %+v", state)
+	}
+	if state.Index != 4 {
+		t.Errorf("expected index 4, got %d", state.Index)
+	}
+}
+
+func TestPaginatorSkipUnknownCount(t *testing.T) {
+	transport := &mockTransport{}
+	transport.Expect("https://pokeapi.co/api/v2/pokemon", http.StatusInternalServerError, "")
+
+	sdk := pokesdk.New(pokesdk.Config{
+		Client: &http.Client{Transport: transport},
+	})
+
+	p := sdk.ListPokemon()
+	p.ErrorPolicy = pokesdk.PageErrorPolicy{Skip: true}
+
+	// Without a count from an earlier page there is no way to know where the
+	// list ends, so iteration stops.
+	_, errs := collectNames(t, p)
+	if len(errs) != 1 {
+		t.Errorf("expected 1 error, got %v", errs)
+	}
+}
+
+func TestPaginatorRestore(t *testing.T) {
+	ctx := context.Background()
+
+	transport := &mockTransport{}
+	transport.Expect("https://pokeapi.co/api/v2/pokemon", http.StatusOK, listResultPage1)
+	transport.Expect("https://pokeapi.co/api/v2/pokemon", http.StatusOK, listResultPage1)
+	// The first iteration may fetch the second page in the background before
+	// it is canceled.
+	transport.Expect("https://pokeapi.co/api/v2/pokemon?offset=20&limit=20", http.StatusOK, listResultPage2)
+	transport.Expect("https://pokeapi.co/api/v2/pokemon?offset=20&limit=20", http.StatusOK, listResultPage2)
+
+	sdk := pokesdk.New(pokesdk.Config{
+		Client: &http.Client{Transport: transport},
+	})
+
+	// Process two items, then "crash" and save the checkpoint.
+	var checkpoint []byte
+	iter, cancel := sdk.ListPokemon().AllWithCancel(ctx)
+	for result := range iter {
+		if result.Error != nil {
+			t.Fatalf("unexpected error: %v", result.Error)
+		}
+		if result.Index == 1 {
+			checkpoint, _ = json.Marshal(result.State)
+			cancel()
+			break
+		}
+	}
+
+	var state pokesdk.PaginatorState
+	if err := json.Unmarshal(checkpoint, &state); err != nil {
+		t.Fatalf("failed to decode checkpoint: %v", err)
+	}
+
+	p := sdk.ListPokemon()
+	p.Restore(state)
+
+	indexes := []int{}
+	names := []string{}
+	for result := range p.All(ctx) {
+		if result.Error != nil {
+			t.Fatalf("unexpected error: %v", result.Error)
+		}
+		indexes = append(indexes, result.Index)
+		names = append(names, result.Value.Name)
+	}
+
+	if !reflect.DeepEqual(names, []string{"venusaur", "charmander", "charmeleon", "charizard"}) {
+		t.Errorf("unexpected results: %v", names)
+	}
+
+	if !reflect.DeepEqual(indexes, []int{2, 3, 4, 5}) {
+		t.Errorf("unexpected indexes: %v", indexes)
+	}
+}
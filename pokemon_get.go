@@ -1,6 +1,9 @@
 package pokesdk
 
-import "context"
+import (
+	"context"
+	"encoding/json"
+)
 
 type Abilities struct {
 	IsHidden bool      `json:"is_hidden"`
@@ -9,13 +12,13 @@ type Abilities struct {
 }
 
 type GameIndices struct {
-	GameIndex int       `json:"game_index"`
-	Version   NamedLink `json:"version"`
+	GameIndex int         `json:"game_index"`
+	Version   VersionLink `json:"version"`
 }
 
 type VersionDetails struct {
-	Rarity  int       `json:"rarity"`
-	Version NamedLink `json:"version"`
+	Rarity  int         `json:"rarity"`
+	Version VersionLink `json:"version"`
 }
 
 type HeldItems struct {
@@ -24,9 +27,10 @@ type HeldItems struct {
 }
 
 type VersionGroupDetails struct {
-	LevelLearnedAt  int       `json:"level_learned_at"`
-	VersionGroup    NamedLink `json:"version_group"`
-	MoveLearnMethod NamedLink `json:"move_learn_method"`
+	LevelLearnedAt  int             `json:"level_learned_at"`
+	VersionGroup    NamedLink       `json:"version_group"`
+	MoveLearnMethod LearnMethodLink `json:"move_learn_method"`
+	Order           *int            `json:"order"`
 }
 
 type Moves struct {
@@ -34,28 +38,20 @@ type Moves struct {
 	VersionGroupDetails []VersionGroupDetails `json:"version_group_details"`
 }
 
-type Sprites struct {
-	BackDefault      string                               `json:"back_default"`
-	BackFemale       string                               `json:"back"`
-	BackShiny        string                               `json:"back_shiny"`
-	BackShinyFemale  string                               `json:"back_shiny_female"`
-	FrontDefault     string                               `json:"front_default"`
-	FrontFemale      string                               `json:"front"`
-	FrontShiny       string                               `json:"front_shiny"`
-	FrontShinyFemale string                               `json:"front_shiny_female"`
-	Other            map[string]map[string]string         `json:"other"`
-	Versions         map[string]map[string]map[string]any `json:"versions"`
-}
-
 type Stats struct {
-	BaseStat int       `json:"base_stat"`
-	Effort   int       `json:"effort"`
-	Stat     NamedLink `json:"stat"`
+	BaseStat int      `json:"base_stat"`
+	Effort   int      `json:"effort"`
+	Stat     StatLink `json:"stat"`
 }
 
 type Types struct {
-	Slot int       `json:"slot"`
-	Type NamedLink `json:"type"`
+	Slot int      `json:"slot"`
+	Type TypeLink `json:"type"`
+}
+
+type PastAbilities struct {
+	Generation NamedLink   `json:"generation"`
+	Abilities  []Abilities `json:"abilities"`
 }
 
 type PastTypes struct {
@@ -84,11 +80,42 @@ type Pokemon struct {
 	Stats                  []Stats           `json:"stats"`
 	Types                  []Types           `json:"types"`
 	PastTypes              []PastTypes       `json:"past_types"`
+	PastAbilities          []PastAbilities   `json:"past_abilities"`
+
+	// Extra holds unknown fields, see `Config.KeepUnknownFields`.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// MarshalJSON encodes the Pokemon including any unknown fields in `Extra`.
+func (p Pokemon) MarshalJSON() ([]byte, error) {
+	type plain Pokemon
+	return marshalWithExtra(plain(p), p.Extra)
 }
 
 // GetPokemon returns a single Pokemon from the API.
 //
 //	pikachu, err := sdk.GetPokemon(ctx, "pikachu")
-func (s *SDK) GetPokemon(ctx context.Context, name string) (*Pokemon, error) {
-	return Follow[Pokemon](ctx, s, s.baseURL+"/api/v2/pokemon/"+name)
+func (s *SDK) GetPokemon(ctx context.Context, name string, opts ...CallOption) (*Pokemon, error) {
+	value, _, err := s.GetPokemonWithResponse(ctx, name, opts...)
+	return value, err
+}
+
+// GetPokemonWithResponse is like `GetPokemon` but also returns metadata about the
+// response. See `FollowWithResponse`.
+//
+//	pika, resp, err := sdk.GetPokemonWithResponse(ctx, "pikachu")
+func (s *SDK) GetPokemonWithResponse(ctx context.Context, name string, opts ...CallOption) (*Pokemon, *Response, error) {
+	return FollowWithResponse[Pokemon](ctx, s, s.baseURL+"/api/v2/pokemon/"+name, opts...)
+}
+
+// GetPokemonBatch gets many pokemon concurrently, returning them in the same
+// order as `names`. See `FollowBatch` for options and error handling.
+//
+//	pokemon, err := sdk.GetPokemonBatch(ctx, []string{"pikachu", "eevee"})
+func (s *SDK) GetPokemonBatch(ctx context.Context, names []string, opts ...BatchOption) ([]*Pokemon, error) {
+	urls := make([]string, len(names))
+	for i, name := range names {
+		urls[i] = s.baseURL + "/api/v2/pokemon/" + name
+	}
+	return FollowBatch[Pokemon](ctx, s, urls, opts...)
 }
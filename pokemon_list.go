@@ -10,9 +10,6 @@ package pokesdk
 //		}
 //		fmt.Printf("Pokemon: %s\n", result.Value.Name)
 //	}
-func (s *SDK) ListPokemon() *Paginator[NamedLink] {
-	return &Paginator[NamedLink]{
-		sdk: s,
-		url: s.baseURL + "/api/v2/pokemon",
-	}
+func (s *SDK) ListPokemon(opts ...CallOption) *Paginator[NamedLink] {
+	return newPaginator[NamedLink](s, s.baseURL+"/api/v2/pokemon", opts)
 }
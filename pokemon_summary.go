@@ -0,0 +1,126 @@
+package pokesdk
+
+import "sort"
+
+// BaseStat returns the base value of the given stat, or zero if the Pokemon
+// does not have it.
+//
+//	hp := pika.BaseStat(pokesdk.StatHP)
+func (p *Pokemon) BaseStat(name StatName) int {
+	for _, s := range p.Stats {
+		if s.Stat.Name == name {
+			return s.BaseStat
+		}
+	}
+	return 0
+}
+
+// StatTotal returns the sum of all base stats.
+func (p *Pokemon) StatTotal() int {
+	total := 0
+	for _, s := range p.Stats {
+		total += s.BaseStat
+	}
+	return total
+}
+
+// TypeNames returns the names of the Pokemon's types ordered by slot.
+func (p *Pokemon) TypeNames() []TypeName {
+	types := make([]Types, len(p.Types))
+	copy(types, p.Types)
+	sort.SliceStable(types, func(i, j int) bool {
+		return types[i].Slot < types[j].Slot
+	})
+
+	names := make([]TypeName, 0, len(types))
+	for _, t := range types {
+		names = append(names, t.Type.Name)
+	}
+	return names
+}
+
+// PrimaryType returns the name of the Pokemon's first type, or an empty
+// string if it has no types.
+func (p *Pokemon) PrimaryType() TypeName {
+	if names := p.TypeNames(); len(names) > 0 {
+		return names[0]
+	}
+	return ""
+}
+
+// HiddenAbility returns the Pokemon's hidden ability, if it has one.
+func (p *Pokemon) HiddenAbility() (NamedLink, bool) {
+	for _, a := range p.Abilities {
+		if a.IsHidden {
+			return a.Ability, true
+		}
+	}
+	return NamedLink{}, false
+}
+
+// HeightMeters returns the Pokemon's height in meters. The API reports height
+// in decimetres.
+func (p *Pokemon) HeightMeters() float64 {
+	return float64(p.Height) / 10
+}
+
+// WeightKg returns the Pokemon's weight in kilograms. The API reports weight
+// in hectograms.
+func (p *Pokemon) WeightKg() float64 {
+	return float64(p.Weight) / 10
+}
+
+// LearnsMove returns whether the Pokemon can learn the given move by any
+// method in any version group.
+func (p *Pokemon) LearnsMove(name string) bool {
+	for _, m := range p.Moves {
+		if m.Move.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Summary is a compact representation of a Pokemon suitable for
+// serialization, e.g. in list views or caches.
+type Summary struct {
+	ID            int              `json:"id"`
+	Name          string           `json:"name"`
+	Types         []TypeName       `json:"types"`
+	Abilities     []string         `json:"abilities"`
+	HiddenAbility string           `json:"hidden_ability,omitempty"`
+	Stats         map[StatName]int `json:"stats"`
+	StatTotal     int              `json:"stat_total"`
+	HeightMeters  float64          `json:"height_m"`
+	WeightKg      float64          `json:"weight_kg"`
+	Sprite        string           `json:"sprite,omitempty"`
+}
+
+// Summary returns a compact summary of the Pokemon.
+func (p *Pokemon) Summary() Summary {
+	summary := Summary{
+		ID:           p.ID,
+		Name:         p.Name,
+		Types:        p.TypeNames(),
+		Abilities:    []string{},
+		Stats:        make(map[StatName]int, len(p.Stats)),
+		StatTotal:    p.StatTotal(),
+		HeightMeters: p.HeightMeters(),
+		WeightKg:     p.WeightKg(),
+		Sprite:       p.Sprites.FrontDefault,
+	}
+
+	for _, a := range p.Abilities {
+		if a.IsHidden {
+			summary.HiddenAbility = a.Ability.Name
+			continue
+		}
+		summary.Abilities = append(summary.Abilities, a.Ability.Name)
+	}
+
+	for _, s := range p.Stats {
+		summary.Stats[s.Stat.Name] = s.BaseStat
+	}
+
+	return summary
+}
@@ -0,0 +1,102 @@
+package pokesdk_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/danielgtaylor/pokesdk"
+)
+
+const pikachuSummary = `{
+	"id": 25,
+	"name": "pikachu",
+	"height": 4,
+	"weight": 60,
+	"abilities": [
+		{"is_hidden": true, "slot": 3, "ability": {"name": "lightning-rod"}},
+		{"is_hidden": false, "slot": 1, "ability": {"name": "static"}}
+	],
+	"moves": [{"move": {"name": "thunderbolt"}, "version_group_details": []}],
+	"sprites": {"front_default": "https://example.com/25.png"},
+	"stats": [
+		{"base_stat": 35, "stat": {"name": "hp"}},
+		{"base_stat": 55, "stat": {"name": "attack"}},
+		{"base_stat": 90, "stat": {"name": "speed"}}
+	],
+	"types": [
+		{"slot": 2, "type": {"name": "flying"}},
+		{"slot": 1, "type": {"name": "electric"}}
+	]
+}`
+
+func TestPokemonAccessors(t *testing.T) {
+	var pika pokesdk.Pokemon
+	if err := json.Unmarshal([]byte(pikachuSummary), &pika); err != nil {
+		t.Fatal(err)
+	}
+
+	if hp := pika.BaseStat(pokesdk.StatHP); hp != 35 {
+		t.Errorf("expected 35 hp, got %d", hp)
+	}
+
+	if def := pika.BaseStat(pokesdk.StatDefense); def != 0 {
+		t.Errorf("expected missing defense, got %d", def)
+	}
+
+	if total := pika.StatTotal(); total != 180 {
+		t.Errorf("expected stat total 180, got %d", total)
+	}
+
+	if typ := pika.PrimaryType(); typ != pokesdk.TypeElectric {
+		t.Errorf("expected electric primary type, got %s", typ)
+	}
+
+	if ability, ok := pika.HiddenAbility(); !ok || ability.Name != "lightning-rod" {
+		t.Errorf("expected lightning-rod hidden ability, got %v", ability)
+	}
+
+	if h := pika.HeightMeters(); h != 0.4 {
+		t.Errorf("expected 0.4m, got %v", h)
+	}
+
+	if w := pika.WeightKg(); w != 6 {
+		t.Errorf("expected 6kg, got %v", w)
+	}
+
+	if !pika.LearnsMove("thunderbolt") || pika.LearnsMove("surf") {
+		t.Errorf("unexpected learnable moves")
+	}
+
+	var empty pokesdk.Pokemon
+	if empty.PrimaryType() != "" {
+		t.Errorf("expected no primary type")
+	}
+	if _, ok := empty.HiddenAbility(); ok {
+		t.Errorf("expected no hidden ability")
+	}
+}
+
+func TestPokemonSummary(t *testing.T) {
+	var pika pokesdk.Pokemon
+	if err := json.Unmarshal([]byte(pikachuSummary), &pika); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := pokesdk.Summary{
+		ID:            25,
+		Name:          "pikachu",
+		Types:         []pokesdk.TypeName{pokesdk.TypeElectric, pokesdk.TypeFlying},
+		Abilities:     []string{"static"},
+		HiddenAbility: "lightning-rod",
+		Stats:         map[pokesdk.StatName]int{pokesdk.StatHP: 35, pokesdk.StatAttack: 55, pokesdk.StatSpeed: 90},
+		StatTotal:     180,
+		HeightMeters:  0.4,
+		WeightKg:      6,
+		Sprite:        "https://example.com/25.png",
+	}
+
+	if summary := pika.Summary(); !reflect.DeepEqual(summary, expected) {
+		t.Errorf("unexpected summary: %+v", summary)
+	}
+}
@@ -0,0 +1,179 @@
+package pokesdk
+
+import "strings"
+
+// VersionGroupInfo describes a version group, the generation it belongs to,
+// and the game versions it contains.
+type VersionGroupInfo struct {
+	Generation string
+	Versions   []VersionName
+}
+
+// VersionGroups maps known version group names to their generation and game
+// versions. It is used to scope version-specific Pokemon data without extra
+// API calls. New version groups can be added here as they are released.
+var VersionGroups = map[string]VersionGroupInfo{
+	"red-blue":                            {"generation-i", []VersionName{"red", "blue"}},
+	"yellow":                              {"generation-i", []VersionName{"yellow"}},
+	"gold-silver":                         {"generation-ii", []VersionName{"gold", "silver"}},
+	"crystal":                             {"generation-ii", []VersionName{"crystal"}},
+	"ruby-sapphire":                       {"generation-iii", []VersionName{"ruby", "sapphire"}},
+	"emerald":                             {"generation-iii", []VersionName{"emerald"}},
+	"firered-leafgreen":                   {"generation-iii", []VersionName{"firered", "leafgreen"}},
+	"colosseum":                           {"generation-iii", []VersionName{"colosseum"}},
+	"xd":                                  {"generation-iii", []VersionName{"xd"}},
+	"diamond-pearl":                       {"generation-iv", []VersionName{"diamond", "pearl"}},
+	"platinum":                            {"generation-iv", []VersionName{"platinum"}},
+	"heartgold-soulsilver":                {"generation-iv", []VersionName{"heartgold", "soulsilver"}},
+	"black-white":                         {"generation-v", []VersionName{"black", "white"}},
+	"black-2-white-2":                     {"generation-v", []VersionName{"black-2", "white-2"}},
+	"x-y":                                 {"generation-vi", []VersionName{"x", "y"}},
+	"omega-ruby-alpha-sapphire":           {"generation-vi", []VersionName{"omega-ruby", "alpha-sapphire"}},
+	"sun-moon":                            {"generation-vii", []VersionName{"sun", "moon"}},
+	"ultra-sun-ultra-moon":                {"generation-vii", []VersionName{"ultra-sun", "ultra-moon"}},
+	"lets-go-pikachu-lets-go-eevee":       {"generation-vii", []VersionName{"lets-go-pikachu", "lets-go-eevee"}},
+	"sword-shield":                        {"generation-viii", []VersionName{"sword", "shield"}},
+	"the-isle-of-armor":                   {"generation-viii", []VersionName{"the-isle-of-armor"}},
+	"the-crown-tundra":                    {"generation-viii", []VersionName{"the-crown-tundra"}},
+	"brilliant-diamond-and-shining-pearl": {"generation-viii", []VersionName{"brilliant-diamond", "shining-pearl"}},
+	"legends-arceus":                      {"generation-viii", []VersionName{"legends-arceus"}},
+	"scarlet-violet":                      {"generation-ix", []VersionName{"scarlet", "violet"}},
+	"the-teal-mask":                       {"generation-ix", []VersionName{"the-teal-mask"}},
+	"the-indigo-disk":                     {"generation-ix", []VersionName{"the-indigo-disk"}},
+}
+
+// VersionMove is a move that can be learned in a specific version group,
+// along with how and at what level it is learned.
+type VersionMove struct {
+	Move            NamedLink       `json:"move"`
+	LevelLearnedAt  int             `json:"level_learned_at"`
+	MoveLearnMethod LearnMethodLink `json:"move_learn_method"`
+}
+
+// VersionHeldItem is an item a wild Pokemon may hold in a specific version.
+type VersionHeldItem struct {
+	Item    NamedLink   `json:"item"`
+	Version VersionLink `json:"version"`
+	Rarity  int         `json:"rarity"`
+}
+
+// VersionGroupView is a projection of a Pokemon's data scoped to a single
+// version group, as returned by `Pokemon.InVersionGroup`.
+type VersionGroupView struct {
+	VersionGroup string            `json:"version_group"`
+	Generation   string            `json:"generation"`
+	Versions     []VersionName     `json:"versions"`
+	Moves        []VersionMove     `json:"moves"`
+	HeldItems    []VersionHeldItem `json:"held_items"`
+	GameIndices  []GameIndices     `json:"game_indices"`
+	Types        []Types           `json:"types"`
+}
+
+// InVersionGroup returns the Pokemon's moves, held items, game indices and
+// types as they were in the given version group. Version groups not listed in
+// `VersionGroups` only have their moves filtered.
+//
+//	view := pika.InVersionGroup("red-blue")
+//	for _, m := range view.Moves {
+//		fmt.Println(m.Move.Name, m.MoveLearnMethod.Name, m.LevelLearnedAt)
+//	}
+func (p *Pokemon) InVersionGroup(name string) *VersionGroupView {
+	info := VersionGroups[name]
+	view := &VersionGroupView{
+		VersionGroup: name,
+		Generation:   info.Generation,
+		Versions:     info.Versions,
+		Moves:        []VersionMove{},
+		HeldItems:    []VersionHeldItem{},
+		GameIndices:  []GameIndices{},
+		Types:        p.TypesInGeneration(info.Generation),
+	}
+
+	for _, move := range p.Moves {
+		for _, detail := range move.VersionGroupDetails {
+			if detail.VersionGroup.Name == name {
+				view.Moves = append(view.Moves, VersionMove{
+					Move:            move.Move,
+					LevelLearnedAt:  detail.LevelLearnedAt,
+					MoveLearnMethod: detail.MoveLearnMethod,
+				})
+			}
+		}
+	}
+
+	inGroup := map[VersionName]bool{}
+	for _, version := range info.Versions {
+		inGroup[version] = true
+	}
+
+	for _, item := range p.HeldItems {
+		for _, detail := range item.VersionDetails {
+			if inGroup[detail.Version.Name] {
+				view.HeldItems = append(view.HeldItems, VersionHeldItem{
+					Item:    item.Item,
+					Version: detail.Version,
+					Rarity:  detail.Rarity,
+				})
+			}
+		}
+	}
+
+	for _, index := range p.GameIndices {
+		if inGroup[index.Version.Name] {
+			view.GameIndices = append(view.GameIndices, index)
+		}
+	}
+
+	return view
+}
+
+// TypesInGeneration returns the Pokemon's types as they were in the given
+// generation, e.g. `generation-v`, using `PastTypes`. The current types are
+// returned for unknown generations or if the types never changed.
+func (p *Pokemon) TypesInGeneration(generation string) []Types {
+	gen := GenerationNumber(generation)
+	if gen == 0 {
+		return p.Types
+	}
+
+	// Past types apply to every generation up to and including the one they
+	// list, so the earliest entry at or after the requested one wins.
+	var types []Types
+	best := 0
+	for _, past := range p.PastTypes {
+		n := GenerationNumber(past.Generation.Name)
+		if n >= gen && (best == 0 || n < best) {
+			best = n
+			types = past.Types
+		}
+	}
+
+	if types == nil {
+		return p.Types
+	}
+	return types
+}
+
+// GenerationNumber returns the number of a generation given its name, e.g.
+// `generation-iv` returns 4. It returns 0 for unknown names.
+func GenerationNumber(name string) int {
+	numeral, ok := strings.CutPrefix(name, "generation-")
+	if !ok || numeral == "" {
+		return 0
+	}
+
+	values := map[byte]int{'i': 1, 'v': 5, 'x': 10, 'l': 50}
+	total := 0
+	for i := 0; i < len(numeral); i++ {
+		v := values[numeral[i]]
+		if v == 0 {
+			return 0
+		}
+		if i+1 < len(numeral) && values[numeral[i+1]] > v {
+			total -= v
+		} else {
+			total += v
+		}
+	}
+	return total
+}
@@ -0,0 +1,132 @@
+package pokesdk_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/danielgtaylor/pokesdk"
+)
+
+const clefairyVersions = `{
+	"name": "clefairy",
+	"game_indices": [
+		{"game_index": 4, "version": {"name": "red"}},
+		{"game_index": 4, "version": {"name": "blue"}},
+		{"game_index": 35, "version": {"name": "gold"}}
+	],
+	"held_items": [
+		{
+			"item": {"name": "moon-stone"},
+			"version_details": [
+				{"rarity": 5, "version": {"name": "red"}},
+				{"rarity": 5, "version": {"name": "diamond"}}
+			]
+		}
+	],
+	"moves": [
+		{
+			"move": {"name": "pound"},
+			"version_group_details": [
+				{"level_learned_at": 1, "move_learn_method": {"name": "level-up"}, "version_group": {"name": "red-blue"}},
+				{"level_learned_at": 1, "move_learn_method": {"name": "level-up"}, "version_group": {"name": "sword-shield"}}
+			]
+		},
+		{
+			"move": {"name": "moonblast"},
+			"version_group_details": [
+				{"level_learned_at": 40, "move_learn_method": {"name": "level-up"}, "version_group": {"name": "sword-shield"}}
+			]
+		}
+	],
+	"types": [{"slot": 1, "type": {"name": "fairy"}}],
+	"past_types": [
+		{"generation": {"name": "generation-v"}, "types": [{"slot": 1, "type": {"name": "normal"}}]}
+	]
+}`
+
+func TestInVersionGroup(t *testing.T) {
+	var clefairy pokesdk.Pokemon
+	if err := json.Unmarshal([]byte(clefairyVersions), &clefairy); err != nil {
+		t.Fatal(err)
+	}
+
+	view := clefairy.InVersionGroup("red-blue")
+
+	if view.Generation != "generation-i" {
+		t.Errorf("expected generation-i, got %s", view.Generation)
+	}
+
+	if len(view.Moves) != 1 || view.Moves[0].Move.Name != "pound" || view.Moves[0].MoveLearnMethod.Name != "level-up" {
+		t.Errorf("unexpected moves: %+v", view.Moves)
+	}
+
+	if len(view.HeldItems) != 1 || view.HeldItems[0].Rarity != 5 || view.HeldItems[0].Version.Name != "red" {
+		t.Errorf("unexpected held items: %+v", view.HeldItems)
+	}
+
+	if len(view.GameIndices) != 2 {
+		t.Errorf("expected 2 game indices, got %+v", view.GameIndices)
+	}
+
+	if len(view.Types) != 1 || view.Types[0].Type.Name != "normal" {
+		t.Errorf("expected past normal type, got %+v", view.Types)
+	}
+
+	view = clefairy.InVersionGroup("sword-shield")
+	if len(view.Moves) != 2 {
+		t.Errorf("expected 2 moves, got %+v", view.Moves)
+	}
+	if len(view.Types) != 1 || view.Types[0].Type.Name != "fairy" {
+		t.Errorf("expected current fairy type, got %+v", view.Types)
+	}
+}
+
+func TestInVersionGroupDLC(t *testing.T) {
+	var clefairy pokesdk.Pokemon
+	if err := json.Unmarshal([]byte(`{
+		"game_indices": [
+			{"game_index": 35, "version": {"name": "sword"}},
+			{"game_index": 36, "version": {"name": "the-crown-tundra"}}
+		],
+		"held_items": [
+			{
+				"item": {"name": "moon-stone"},
+				"version_details": [
+					{"rarity": 5, "version": {"name": "sword"}},
+					{"rarity": 50, "version": {"name": "the-crown-tundra"}}
+				]
+			}
+		]
+	}`), &clefairy); err != nil {
+		t.Fatal(err)
+	}
+
+	view := clefairy.InVersionGroup("the-crown-tundra")
+
+	if len(view.Versions) != 1 || view.Versions[0] != pokesdk.VersionTheCrownTundra {
+		t.Errorf("unexpected versions: %v", view.Versions)
+	}
+
+	if len(view.HeldItems) != 1 || view.HeldItems[0].Rarity != 50 {
+		t.Errorf("unexpected held items: %+v", view.HeldItems)
+	}
+
+	if len(view.GameIndices) != 1 || view.GameIndices[0].GameIndex != 36 {
+		t.Errorf("unexpected game indices: %+v", view.GameIndices)
+	}
+}
+
+func TestGenerationNumber(t *testing.T) {
+	for name, expected := range map[string]int{
+		"generation-i":    1,
+		"generation-iv":   4,
+		"generation-viii": 8,
+		"generation-ix":   9,
+		"generation-":     0,
+		"gen-1":           0,
+	} {
+		if n := pokesdk.GenerationNumber(name); n != expected {
+			t.Errorf("expected %s to be %d, got %d", name, expected, n)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package pokesdk
+
+import (
+	"net/http"
+	"time"
+)
+
+// Response holds metadata about an API response alongside its decoded value,
+// e.g. for custom caching or checking `Date`/`ETag` headers.
+type Response struct {
+	StatusCode int
+	Header     http.Header
+
+	// Body is the exact bytes of the response body, after decompression.
+	Body []byte
+
+	// Encoding is the content encoding used to transfer the body, e.g. `gzip`,
+	// or empty if it was not compressed.
+	Encoding string
+
+	// WireBytes is the number of body bytes received over the network. When
+	// compared with the length of `Body` this shows compression savings.
+	WireBytes int64
+
+	// Latency is the time taken to make the request and read the body.
+	Latency time.Duration
+
+	// FromCache is true if a caching transport served the response. This is
+	// detected via the `X-From-Cache` header which common caching transports
+	// set on cached responses.
+	FromCache bool
+
+	// Shared is true if the response was shared with a concurrent identical
+	// request. See `Config.Coalesce`.
+	Shared bool
+}
+
+func newResponse(resp *http.Response, body []byte, latency time.Duration) *Response {
+	r := &Response{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       body,
+		Latency:    latency,
+		FromCache:  resp.Header.Get("X-From-Cache") != "",
+		WireBytes:  int64(len(body)),
+	}
+
+	if b, ok := resp.Body.(*responseBody); ok {
+		r.Encoding = b.encoding
+		r.WireBytes = b.wire.n
+	}
+
+	return r
+}
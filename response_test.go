@@ -0,0 +1,91 @@
+package pokesdk_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/danielgtaylor/pokesdk"
+)
+
+// closeTracker records whether a response body has been closed.
+type closeTracker struct {
+	io.Reader
+	closed bool
+}
+
+func (c *closeTracker) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestGetPokemonWithResponse(t *testing.T) {
+	ctx := context.Background()
+
+	body := &closeTracker{Reader: strings.NewReader(`{"name":"pikachu"}`)}
+	transport := &mockTransport{}
+	transport.ExpectResponse("https://pokeapi.co/api/v2/pokemon/pikachu", &http.Response{
+		StatusCode: http.StatusOK,
+		Header: http.Header{
+			"Etag":         []string{`"abc123"`},
+			"X-From-Cache": []string{"1"},
+		},
+		Body: body,
+	})
+
+	sdk := pokesdk.New(pokesdk.Config{
+		Client: &http.Client{Transport: transport},
+	})
+
+	pika, resp, err := sdk.GetPokemonWithResponse(ctx, "pikachu")
+	if err != nil {
+		t.Fatalf("failed to get pikachu: %v", err)
+	}
+
+	if pika.Name != "pikachu" {
+		t.Errorf("expected pikachu, got %s", pika.Name)
+	}
+
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("ETag") != `"abc123"` {
+		t.Errorf("unexpected response metadata: %+v", resp)
+	}
+
+	if string(resp.Body) != `{"name":"pikachu"}` {
+		t.Errorf("unexpected body: %s", resp.Body)
+	}
+
+	if !resp.FromCache {
+		t.Errorf("expected response from cache")
+	}
+
+	if resp.Latency <= 0 {
+		t.Errorf("expected latency to be recorded")
+	}
+
+	if !body.closed {
+		t.Errorf("expected body to be closed")
+	}
+}
+
+func TestFollowWithResponseError(t *testing.T) {
+	ctx := context.Background()
+
+	transport := &mockTransport{}
+	transport.Expect("https://pokeapi.co/api/v2/pokemon/missingno", http.StatusNotFound, "Not Found")
+
+	sdk := pokesdk.New(pokesdk.Config{
+		Client: &http.Client{Transport: transport},
+	})
+
+	_, resp, err := pokesdk.FollowWithResponse[pokesdk.Pokemon](ctx, sdk, "https://pokeapi.co/api/v2/pokemon/missingno")
+	if !errors.Is(err, pokesdk.APIError) {
+		t.Fatalf("expected API error, got %v", err)
+	}
+
+	if resp == nil || resp.StatusCode != http.StatusNotFound || string(resp.Body) != "Not Found" || resp.FromCache {
+		t.Errorf("unexpected response metadata: %+v", resp)
+	}
+}
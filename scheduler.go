@@ -0,0 +1,131 @@
+package pokesdk
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+)
+
+// scheduler limits the number of concurrent requests. Waiting requests are
+// started by priority, then in the order they arrived.
+type scheduler struct {
+	mu      sync.Mutex
+	limit   int
+	running int
+	waiting waitQueue
+	seq     int
+}
+
+type waiter struct {
+	priority int
+	seq      int
+	index    int
+	granted  bool
+	ready    chan struct{}
+}
+
+// acquire waits for a slot and returns a function which releases it. It
+// never waits when there is no limit.
+func (s *scheduler) acquire(ctx context.Context, priority int) (func(), error) {
+	if s == nil || s.limit <= 0 {
+		return func() {}, nil
+	}
+
+	s.mu.Lock()
+	if s.running < s.limit && len(s.waiting) == 0 {
+		s.running++
+		s.mu.Unlock()
+		return s.releaser(), nil
+	}
+	s.seq++
+	w := &waiter{priority: priority, seq: s.seq, ready: make(chan struct{})}
+	heap.Push(&s.waiting, w)
+	s.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return s.releaser(), nil
+	case <-ctx.Done():
+		s.mu.Lock()
+		granted := w.granted
+		if !granted {
+			heap.Remove(&s.waiting, w.index)
+		}
+		s.mu.Unlock()
+		if granted {
+			// The slot was handed over just as the wait was canceled.
+			s.release()
+		}
+		return nil, ctx.Err()
+	}
+}
+
+// releaser returns a function which releases a slot once, however often it
+// is called.
+func (s *scheduler) releaser() func() {
+	once := sync.Once{}
+	return func() {
+		once.Do(s.release)
+	}
+}
+
+// release hands the slot to the next waiting request, if any.
+func (s *scheduler) release() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.waiting) == 0 {
+		s.running--
+		return
+	}
+	w := heap.Pop(&s.waiting).(*waiter)
+	w.granted = true
+	close(w.ready)
+}
+
+// releaseCloser releases a slot when a response body is closed.
+type releaseCloser func()
+
+func (r releaseCloser) Close() error {
+	r()
+	return nil
+}
+
+// waitingCount returns the number of requests waiting for a slot.
+func (s *scheduler) waitingCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.waiting)
+}
+
+// waitQueue is a `container/heap` of waiters with the highest priority first.
+type waitQueue []*waiter
+
+func (q waitQueue) Len() int { return len(q) }
+
+func (q waitQueue) Less(i, j int) bool {
+	if q[i].priority != q[j].priority {
+		return q[i].priority > q[j].priority
+	}
+	return q[i].seq < q[j].seq
+}
+
+func (q waitQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *waitQueue) Push(x any) {
+	w := x.(*waiter)
+	w.index = len(*q)
+	*q = append(*q, w)
+}
+
+func (q *waitQueue) Pop() any {
+	old := *q
+	w := old[len(old)-1]
+	old[len(old)-1] = nil
+	*q = old[:len(old)-1]
+	return w
+}
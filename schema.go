@@ -0,0 +1,183 @@
+package pokesdk
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// SchemaError is returned in strict mode when a response does not match the
+// SDK's models, e.g. because the API added or removed a field.
+var SchemaError = errors.New("schema mismatch")
+
+// SchemaIssue describes a single field which differs between a response and
+// the model it was decoded into.
+type SchemaIssue struct {
+	// Path is the dotted path to the field, e.g. `moves[].move.name`.
+	Path string
+
+	// Unknown is true if the field is in the response but not the model, and
+	// false if the model expects a field which is missing from the response.
+	Unknown bool
+}
+
+func (i SchemaIssue) String() string {
+	if i.Unknown {
+		return "unknown field " + i.Path
+	}
+	return "missing field " + i.Path
+}
+
+// checkSchema compares a raw response body against the model type `t` and
+// returns any unknown or missing fields, sorted by path.
+func checkSchema(codec Codec, data []byte, t reflect.Type) ([]SchemaIssue, error) {
+	var raw any
+	if err := codec.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	found := map[SchemaIssue]bool{}
+	walkSchema(raw, t, "", found)
+
+	issues := make([]SchemaIssue, 0, len(found))
+	for issue := range found {
+		issues = append(issues, issue)
+	}
+	sort.Slice(issues, func(i, j int) bool {
+		return issues[i].Path < issues[j].Path
+	})
+	return issues, nil
+}
+
+// schemaField is a field of a model as seen by the schema check.
+type schemaField struct {
+	Type reflect.Type
+
+	// Optional fields are not reported when missing from a response.
+	Optional bool
+}
+
+// schemaFields returns the JSON keys of a struct type mapped to their
+// fields, including those of embedded structs.
+func schemaFields(t reflect.Type) map[string]schemaField {
+	fields := map[string]schemaField{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" || !f.IsExported() {
+			continue
+		}
+		if f.Anonymous && name == "" {
+			for k, v := range schemaFields(f.Type) {
+				fields[k] = v
+			}
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = schemaField{
+			Type:     f.Type,
+			Optional: strings.Contains(","+opts+",", ",omitempty,") || isOptional(f.Type),
+		}
+	}
+	return fields
+}
+
+var unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// isOptional returns whether values of type `t` may be left out of a
+// response. Pointers may be missing, and types with their own unmarshaler or
+// an `Extra` field, like the sprite sets, only model the keys which some
+// responses have.
+func isOptional(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer || reflect.PointerTo(t).Implements(unmarshalerType) {
+		return true
+	}
+	if t.Kind() == reflect.Struct {
+		if f, ok := t.FieldByName("Extra"); ok && f.Type == extraType {
+			return true
+		}
+	}
+	return false
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func walkSchema(raw any, t reflect.Type, path string, found map[SchemaIssue]bool) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if raw == nil || t == rawMessageType {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		obj, ok := raw.(map[string]any)
+		if !ok {
+			return
+		}
+		fields := schemaFields(t)
+		for k, v := range obj {
+			if f, ok := fields[k]; ok {
+				walkSchema(v, f.Type, joinPath(path, k), found)
+			} else {
+				found[SchemaIssue{Path: joinPath(path, k), Unknown: true}] = true
+			}
+		}
+		for k, f := range fields {
+			if _, ok := obj[k]; !ok && !f.Optional {
+				found[SchemaIssue{Path: joinPath(path, k)}] = true
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if items, ok := raw.([]any); ok {
+			for _, item := range items {
+				walkSchema(item, t.Elem(), path+"[]", found)
+			}
+		}
+	case reflect.Map:
+		if obj, ok := raw.(map[string]any); ok {
+			for _, v := range obj {
+				walkSchema(v, t.Elem(), joinPath(path, "*"), found)
+			}
+		}
+	}
+}
+
+// validate checks decoded data against the model type of `v` when strict mode
+// or a schema hook is configured.
+func (s *SDK) validate(codec Codec, url string, data []byte, v any) error {
+	if !s.strict && s.onSchemaIssues == nil {
+		return nil
+	}
+
+	issues, err := checkSchema(codec, data, reflect.TypeOf(v).Elem())
+	if err != nil || len(issues) == 0 {
+		return err
+	}
+
+	if s.onSchemaIssues != nil {
+		s.onSchemaIssues(url, issues)
+	}
+
+	if s.strict {
+		msgs := make([]string, 0, len(issues))
+		for _, issue := range issues {
+			msgs = append(msgs, issue.String())
+		}
+		return fmt.Errorf("%s: %s: %w", url, strings.Join(msgs, ", "), SchemaError)
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package pokesdk_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/danielgtaylor/pokesdk"
+)
+
+const fullGeneration = `{
+	"id": 1,
+	"name": "generation-i",
+	"abilities": [],
+	"main_region": {"name": "kanto", "url": "https://pokeapi.co/api/v2/region/1/"},
+	"moves": [{"name": "pound", "url": "https://pokeapi.co/api/v2/move/1/"}],
+	"names": [{"name": "Generation I", "language": {"name": "en", "url": "https://pokeapi.co/api/v2/language/9/"}}],
+	"pokemon_species": [],
+	"types": [],
+	"version_groups": []
+}`
+
+const driftedGeneration = `{
+	"id": 1,
+	"name": "generation-i",
+	"abilities": [],
+	"main_region": {"name": "kanto", "url": "https://pokeapi.co/api/v2/region/1/"},
+	"moves": [{"name": "pound"}],
+	"names": [],
+	"pokemon_species": [],
+	"types": [],
+	"version_groups": [],
+	"release_year": 1996
+}`
+
+func TestStrictMode(t *testing.T) {
+	ctx := context.Background()
+
+	transport := &mockTransport{}
+	transport.Expect("https://pokeapi.co/api/v2/generation/generation-i", http.StatusOK, fullGeneration)
+	transport.Expect("https://pokeapi.co/api/v2/generation/generation-i", http.StatusOK, driftedGeneration)
+
+	sdk := pokesdk.New(pokesdk.Config{
+		Client: &http.Client{Transport: transport},
+		Strict: true,
+	})
+
+	if _, err := sdk.GetGeneration(ctx, "generation-i"); err != nil {
+		t.Fatalf("expected matching schema: %v", err)
+	}
+
+	_, err := sdk.GetGeneration(ctx, "generation-i")
+	if !errors.Is(err, pokesdk.SchemaError) {
+		t.Fatalf("expected schema error, got %v", err)
+	}
+}
+
+func TestStrictModePokemon(t *testing.T) {
+	pikachu, err := os.ReadFile("testdata/pokemon-pikachu.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	transport := &mockTransport{}
+	transport.Expect("https://pokeapi.co/api/v2/pokemon/pikachu", http.StatusOK, string(pikachu))
+
+	sdk := pokesdk.New(pokesdk.Config{
+		Client: &http.Client{Transport: transport},
+		Strict: true,
+	})
+
+	pika, err := sdk.GetPokemon(context.Background(), "pikachu")
+	if err != nil {
+		t.Fatalf("expected matching schema: %v", err)
+	}
+
+	if url := pika.Sprites.Other.OfficialArtwork.FrontShiny; url == nil || *url == "" {
+		t.Errorf("expected official artwork, got %v", url)
+	}
+}
+
+func TestSchemaHook(t *testing.T) {
+	ctx := context.Background()
+
+	transport := &mockTransport{}
+	transport.Expect("https://pokeapi.co/api/v2/generation/generation-i", http.StatusOK, driftedGeneration)
+
+	var issues []pokesdk.SchemaIssue
+	sdk := pokesdk.New(pokesdk.Config{
+		Client: &http.Client{Transport: transport},
+		OnSchemaIssues: func(url string, found []pokesdk.SchemaIssue) {
+			issues = found
+		},
+	})
+
+	gen, err := sdk.GetGeneration(ctx, "generation-i")
+	if err != nil {
+		t.Fatalf("expected warning only, got %v", err)
+	}
+
+	if gen.Name != "generation-i" {
+		t.Errorf("expected generation-i, got %s", gen.Name)
+	}
+
+	expected := []pokesdk.SchemaIssue{
+		{Path: "moves[].url"},
+		{Path: "release_year", Unknown: true},
+	}
+	if !reflect.DeepEqual(issues, expected) {
+		t.Errorf("unexpected issues: %v", issues)
+	}
+}
+
+func TestStrictModePagination(t *testing.T) {
+	ctx := context.Background()
+
+	transport := &mockTransport{}
+	transport.Expect("https://pokeapi.co/api/v2/type", http.StatusOK, `{"count":0,"next":null,"previous":null,"results":[],"cursor":"abc"}`)
+
+	sdk := pokesdk.New(pokesdk.Config{
+		Client: &http.Client{Transport: transport},
+		Strict: true,
+	})
+
+	for result := range sdk.ListTypes().All(ctx) {
+		if !errors.Is(result.Error, pokesdk.SchemaError) {
+			t.Errorf("expected schema error, got %v", result.Error)
+		}
+	}
+}
@@ -22,16 +22,24 @@ package pokesdk
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strings"
+	"time"
 )
 
+//go:generate go run ./internal/genenums -o enums_gen.go
+
 // APIError is an error type for API errors, such as 404 not found responses.
 var APIError = errors.New("API error")
 
+// DefaultRetryBackoff is how long to wait before the first retry of a failed
+// request when `Config.Retries` is set.
+var DefaultRetryBackoff = 200 * time.Millisecond
+
 // NamedLink is a common structure for named links in the API that contain a
 // name and a URL.
 type NamedLink struct {
@@ -42,14 +50,177 @@ type NamedLink struct {
 // Config provides optional configuration for the Pokemon API SDK.
 type Config struct {
 	BaseURL string
-	Client  *http.Client
-	// TODO: add auth if desired...
+
+	// Client is used to make requests. By default the SDK builds its own
+	// client from the transport settings below, which are ignored when a
+	// custom client is given.
+	Client *http.Client
+
+	// Timeout limits each request including reading the response body, and
+	// defaults to `DefaultTimeout`. Use a negative value for no timeout.
+	Timeout time.Duration
+
+	// DialTimeout limits establishing a connection, and defaults to five
+	// seconds.
+	DialTimeout time.Duration
+
+	// TLSHandshakeTimeout limits the TLS handshake, and defaults to five
+	// seconds.
+	TLSHandshakeTimeout time.Duration
+
+	// ResponseHeaderTimeout limits waiting for response headers after the
+	// request was sent, and defaults to ten seconds.
+	ResponseHeaderTimeout time.Duration
+
+	// MaxIdleConnsPerHost sets how many keep-alive connections are kept open
+	// per host for reuse, and defaults to 16. Raise it along with
+	// `BatchConcurrency` for large parallel crawls.
+	MaxIdleConnsPerHost int
+
+	// MaxConnsPerHost limits the total connections per host. Zero means no
+	// limit.
+	MaxConnsPerHost int
+
+	// MaxConcurrentRequests limits how many requests the SDK makes at once,
+	// across all calls and with any client. Further requests wait, and start
+	// in order of their `WithPriority`. A request holds its slot until its
+	// response body is closed. Zero means no limit.
+	MaxConcurrentRequests int
+
+	// IdleConnTimeout is how long idle keep-alive connections are kept, and
+	// defaults to 90 seconds.
+	IdleConnTimeout time.Duration
+
+	// DisableHTTP2 disables HTTP/2, which is otherwise used when the server
+	// supports it.
+	DisableHTTP2 bool
+
+	// Languages is the list of preferred languages for localized text, e.g.
+	// `[]string{"ja-Hrkt", "de"}`. See `FallbackChain` for how it is expanded.
+	Languages []string
+
+	// Strict makes decoding fail with `SchemaError` when a response has fields
+	// the models do not know about, or is missing fields the models require.
+	// Optional fields, such as pointers and sprites, may be missing. This is
+	// useful in tests to detect API schema drift.
+	Strict bool
+
+	// OnSchemaIssues is called with any unknown or missing fields found while
+	// decoding a response. It is called even when not in strict mode, so it can
+	// be used to log warnings about schema drift.
+	OnSchemaIssues func(url string, issues []SchemaIssue)
+
+	// KeepUnknownFields fills the `Extra` field of decoded models with any
+	// fields from the API which the models do not know about, so that they
+	// are preserved when re-encoding. The values are kept as the exact bytes
+	// from the response; encode with `SetEscapeHTML(false)` to write them
+	// back unchanged. This takes an extra pass over each response. Unknown
+	// sprite keys are always kept in `Sprites`.
+	KeepUnknownFields bool
+
+	// Codecs maps media types to codecs used to decode responses. The `Accept`
+	// header is built from these and responses are decoded based on their
+	// `Content-Type`. JSON is always supported via `JSONCodec`.
+	Codecs map[string]Codec
+
+	// Decompressors maps content encodings such as `deflate` to functions
+	// which decompress them. The `Accept-Encoding` header is built from these,
+	// and `gzip`, `br` and `zstd` are supported by default. Setting an
+	// encoding to nil disables it.
+	Decompressors map[string]Decompressor
+
+	// MaxResponseBytes limits the size of response bodies after decompression.
+	// Reading past the limit fails with a `ResponseTooLargeError`. Zero means
+	// no limit.
+	MaxResponseBytes int64
+
+	// Coalesce enables sharing a single request between concurrent callers of
+	// `Follow` (and the methods built on it) or GET `Request` calls for the
+	// same URL. Callers of `Follow` share the same decoded value, which must
+	// therefore not be modified. Each caller can still cancel its own wait via
+	// its context. Calls with their own timeout, retries, priority, headers,
+	// query parameters or `NoCache` are never shared.
+	Coalesce bool
+
+	// Credentials authenticate requests, for example when using a mirror
+	// behind an API gateway. They are only sent to the `BaseURL` host.
+	Credentials Credentials
+
+	// Endpoints are additional base URLs serving the API, such as regional
+	// mirrors or the public API as a fallback. Requests for any of them or
+	// `BaseURL`, including links from responses, are routed according to
+	// `EndpointPolicy` and transparently retried on another endpoint when
+	// one fails. Endpoints which keep failing are avoided for a while.
+	Endpoints []Endpoint
+
+	// EndpointPolicy selects the order in which endpoints are tried.
+	EndpointPolicy EndpointPolicy
+
+	// Retries is the number of times a request is retried after a network
+	// error, 5xx or 429 response. The first retry waits `RetryBackoff`, which
+	// doubles for each further retry. Zero disables retries. See
+	// `WithRetries`.
+	Retries int
+
+	// RetryBackoff defaults to `DefaultRetryBackoff`.
+	RetryBackoff time.Duration
+
+	// CircuitBreaker enables failing fast with `ErrCircuitOpen` instead of
+	// waiting on requests to an API which keeps failing. Requests for URLs
+	// outside `BaseURL` and `Endpoints` are not affected.
+	CircuitBreaker *CircuitBreaker
+
+	// AllowedHosts lists hosts besides the `BaseURL` host which `Follow` and
+	// paginators may request, such as `"cdn.example.com:8443"`. Use `"*"` to
+	// allow any host. This protects against following poisoned links from a
+	// mirror or cache. Redirects are checked in the same way, also when using
+	// a custom `Client`.
+	AllowedHosts []string
+
+	// RewritePokeAPI rewrites links to the public API at `PokeAPIBaseURL` to
+	// use `BaseURL` instead before following them. This is useful for mirrors
+	// which return links to the public API. It is applied after `Rewrites`.
+	RewritePokeAPI bool
+
+	// Rewrites are applied to URLs before they are followed, in order, with
+	// the first matching rule winning. See `SDK.RewriteURL`.
+	Rewrites []Rewrite
+
+	// RewriteResponses applies `Rewrites` to URLs inside decoded responses,
+	// so links handed to consumers point at the mirror. See
+	// `SDK.RewriteURLs`.
+	RewriteResponses bool
 }
 
 // SDK is the Pokemon API SDK.
 type SDK struct {
-	baseURL string
-	client  *http.Client
+	baseURL        string
+	client         *http.Client
+	languages      []string
+	strict         bool
+	onSchemaIssues func(url string, issues []SchemaIssue)
+	keepUnknown    bool
+	codecs         map[string]Codec
+	accept         string
+
+	decompressors    map[string]Decompressor
+	acceptEncoding   string
+	maxResponseBytes int64
+
+	coalesce bool
+	flights  flightGroup
+
+	base             *url.URL
+	allowedHosts     map[string]bool
+	rewrites         []Rewrite
+	rewriteResponses bool
+
+	router *endpointRouter
+
+	retries      int
+	retryBackoff time.Duration
+
+	scheduler *scheduler
 }
 
 // New returns a new instance of the Pokemon API SDK.
@@ -59,53 +230,259 @@ func New(config Config) *SDK {
 	}
 
 	if config.Client == nil {
-		config.Client = http.DefaultClient
+		config.Client = newClient(config)
+	}
+
+	if config.RetryBackoff <= 0 {
+		config.RetryBackoff = DefaultRetryBackoff
+	}
+
+	codecs := map[string]Codec{JSONMediaType: JSONCodec}
+	for mt, codec := range config.Codecs {
+		codecs[mt] = codec
+	}
+
+	decompressors := map[string]Decompressor{
+		"gzip": GzipDecompressor,
+		"br":   BrotliDecompressor,
+		"zstd": ZstdDecompressor,
+	}
+	for enc, d := range config.Decompressors {
+		if d == nil {
+			delete(decompressors, enc)
+			continue
+		}
+		decompressors[enc] = d
+	}
+
+	credentials := map[string]Credentials{config.BaseURL: config.Credentials}
+	breakerConfig := CircuitBreaker{}
+	if config.CircuitBreaker != nil {
+		breakerConfig = *config.CircuitBreaker
+	}
+	newEndpoint := func(u string) *endpoint {
+		u = strings.TrimSuffix(u, "/")
+		return &endpoint{url: u, breaker: newBreaker(u, breakerConfig)}
 	}
 
-	return &SDK{
-		baseURL: config.BaseURL,
-		client:  config.Client,
+	router := &endpointRouter{
+		endpoints: []*endpoint{newEndpoint(config.BaseURL)},
+		policy:    config.EndpointPolicy,
+		failFast:  config.CircuitBreaker != nil,
+	}
+	allowedHosts := map[string]bool{}
+	for _, host := range config.AllowedHosts {
+		allowedHosts[strings.ToLower(host)] = true
+	}
+	for _, e := range config.Endpoints {
+		credentials[e.URL] = e.Credentials
+		router.endpoints = append(router.endpoints, newEndpoint(e.URL))
+		if u, err := url.Parse(e.URL); err == nil {
+			allowedHosts[strings.ToLower(u.Host)] = true
+		}
+	}
+
+	config.Client = withCredentials(config.Client, credentials)
+
+	// An invalid base URL is reported when following URLs.
+	base, _ := url.Parse(config.BaseURL)
+
+	rewrites := append([]Rewrite{}, config.Rewrites...)
+	if config.RewritePokeAPI && config.BaseURL != PokeAPIBaseURL {
+		rewrites = append(rewrites, Rewrite{From: PokeAPIBaseURL, To: strings.TrimSuffix(config.BaseURL, "/")})
 	}
+
+	sdk := &SDK{
+		baseURL:        config.BaseURL,
+		client:         config.Client,
+		languages:      config.Languages,
+		strict:         config.Strict,
+		onSchemaIssues: config.OnSchemaIssues,
+		keepUnknown:    config.KeepUnknownFields,
+		codecs:         codecs,
+		accept:         accept(codecs),
+
+		decompressors:    decompressors,
+		acceptEncoding:   acceptEncoding(decompressors),
+		maxResponseBytes: config.MaxResponseBytes,
+
+		coalesce: config.Coalesce,
+
+		base:             base,
+		allowedHosts:     allowedHosts,
+		rewrites:         rewrites,
+		rewriteResponses: config.RewriteResponses,
+
+		router: router,
+
+		retries:      config.Retries,
+		retryBackoff: config.RetryBackoff,
+
+		scheduler: &scheduler{limit: config.MaxConcurrentRequests},
+	}
+	sdk.client = sdk.checkRedirects(sdk.client)
+	return sdk
 }
 
 // Request makes an HTTP request to the given URL with the given method and
 // body using the SDK's client. It returns the response or an error.
 func (s *SDK) Request(ctx context.Context, method, url string, body io.Reader) (*http.Response, error) {
+	if s.coalesce && method == http.MethodGet && body == nil && callOptionsFrom(ctx).shareable() {
+		return s.requestShared(ctx, url)
+	}
+
 	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	// TODO: auth headers could be inserted here.
+	return s.do(req)
+}
+
+// do applies call options to a request and sends it, retrying failures.
+func (s *SDK) do(req *http.Request) (*http.Response, error) {
+	opts := callOptionsFrom(req.Context())
+	opts.apply(req)
+
+	retries := s.retries
+	if opts != nil && opts.retries != nil {
+		retries = *opts.retries
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := s.route(req)
+		if attempt >= retries || !retryable(resp, err) || req.Context().Err() != nil ||
+			errors.Is(err, ErrCircuitOpen) || errors.Is(err, AuthError) ||
+			(req.Body != nil && req.GetBody == nil) {
+			return resp, err
+		}
+
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		select {
+		case <-req.Context().Done():
+			return nil, fmt.Errorf("failed to make request: %w", req.Context().Err())
+		case <-time.After(s.retryBackoff << attempt):
+		}
+
+		if req.GetBody != nil {
+			if req.Body, err = req.GetBody(); err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+		}
+	}
+}
+
+// route sends a request, routing it to a healthy endpoint if it is for one of
+// the configured base URLs and circuit breaking is enabled or there are
+// several endpoints.
+func (s *SDK) route(req *http.Request) (*http.Response, error) {
+	if (len(s.router.endpoints) > 1 || s.router.failFast) && (req.Body == nil || req.GetBody != nil) {
+		if rest, ok := s.router.match(req.URL.String()); ok {
+			return s.doEndpoints(req, rest)
+		}
+	}
+	return s.send(req)
+}
+
+// send sends a request using the SDK's client once there is a free slot for
+// it. Compressed responses are transparently decompressed.
+func (s *SDK) send(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("Accept-Encoding") == "" {
+		req.Header.Set("Accept-Encoding", s.acceptEncoding)
+	}
+
+	priority := 0
+	if opts := callOptionsFrom(req.Context()); opts != nil {
+		priority = opts.priority
+	}
+	release, err := s.scheduler.acquire(req.Context(), priority)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
 
 	resp, err := s.client.Do(req)
 	if err != nil {
+		release()
 		return nil, fmt.Errorf("failed to make request: %w", err)
 	}
 
+	if err := s.wrapBody(resp); err != nil {
+		resp.Body.Close()
+		release()
+		return nil, err
+	}
+	body := resp.Body.(*responseBody)
+	body.closers = append(body.closers, releaseCloser(release))
+
 	return resp, nil
 }
 
 // Follow is a helper function to follow a URL and decode the response into a
 // pointer of the given type. This is useful for following links in API
-// responses hypermedia-style.
+// responses hypermedia-style. Relative URLs are resolved against the base URL,
+// and the URL must be on the base URL host or in `Config.AllowedHosts`.
 //
 //	thing, err := Follow[Thing](ctx, sdk, "https://example.com/things/123")
-func Follow[T any](ctx context.Context, sdk *SDK, url string) (*T, error) {
-	resp, err := sdk.Request(ctx, http.MethodGet, url, nil)
+func Follow[T any](ctx context.Context, sdk *SDK, url string, opts ...CallOption) (*T, error) {
+	value, _, err := FollowWithResponse[T](ctx, sdk, url, opts...)
+	return value, err
+}
+
+// FollowWithResponse is like `Follow` but also returns metadata about the
+// response, such as the status code, headers and raw body. The metadata is
+// returned whenever a response was received, even if decoding fails or the
+// status code indicates an error.
+//
+//	thing, resp, err := FollowWithResponse[Thing](ctx, sdk, "https://example.com/things/123")
+//	fmt.Println(resp.Header.Get("ETag"))
+func FollowWithResponse[T any](ctx context.Context, sdk *SDK, url string, opts ...CallOption) (*T, *Response, error) {
+	url, err := sdk.ResolveURL(url)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
+	ctx, cancel := withCallOptions(ctx, opts)
+	defer cancel()
+
+	if sdk.coalesce && callOptionsFrom(ctx).shareable() {
+		return followShared[T](ctx, sdk, url)
+	}
+	return follow[T](ctx, sdk, url)
+}
+
+// follow makes the request for `FollowWithResponse` and decodes the result.
+func follow[T any](ctx context.Context, sdk *SDK, url string) (*T, *Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", sdk.accept)
+
+	start := time.Now()
+	resp, err := sdk.do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	meta := newResponse(resp, data, time.Since(start))
+
 	if resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("status %d response: %w", resp.StatusCode, APIError)
+		return nil, meta, fmt.Errorf("status %d response: %w", resp.StatusCode, APIError)
 	}
 
-	// TODO: content negotiation could be added here to support more formats.
 	var value *T
-	if err := json.NewDecoder(resp.Body).Decode(&value); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := sdk.decode(resp, url, data, &value); err != nil {
+		return nil, meta, err
 	}
 
-	return value, nil
+	return value, meta, nil
 }
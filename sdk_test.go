@@ -5,15 +5,25 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"sync"
 )
 
 // mockTransport is a mock HTTP transport for testing. It takes a map of URLs to
 // expected responses via `Expect` calls.
 type mockTransport struct {
+	mu        sync.Mutex
 	responses map[string][]*http.Response
+
+	// requests records every request made, in order.
+	requests []*http.Request
 }
 
 func (t *mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.requests = append(t.requests, req)
+
 	// Note: each call pops the first response off the list for the given URL.
 	// This allows for multiple responses to be expected for the same URL.
 	if r, ok := t.responses[req.URL.String()]; ok {
@@ -28,11 +38,20 @@ func (t *mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 
 // Expect adds an expected response for a given URL (including query params).
 func (t *mockTransport) Expect(url string, status int, body string) {
-	if t.responses == nil {
-		t.responses = make(map[string][]*http.Response)
-	}
-	t.responses[url] = append(t.responses[url], &http.Response{
+	t.ExpectResponse(url, &http.Response{
 		StatusCode: status,
 		Body:       io.NopCloser(strings.NewReader(body)),
 	})
 }
+
+// ExpectResponse adds a full expected response for a given URL, e.g. to set
+// custom headers.
+func (t *mockTransport) ExpectResponse(url string, resp *http.Response) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.responses == nil {
+		t.responses = make(map[string][]*http.Response)
+	}
+	t.responses[url] = append(t.responses[url], resp)
+}
@@ -0,0 +1,77 @@
+package pokesdk
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// APIResource is a link to a resource which has no name, such as an
+// evolution chain.
+type APIResource struct {
+	URL string `json:"url"`
+}
+
+type PokedexNumbers struct {
+	EntryNumber int       `json:"entry_number"`
+	Pokedex     NamedLink `json:"pokedex"`
+}
+
+type Varieties struct {
+	IsDefault bool      `json:"is_default"`
+	Pokemon   NamedLink `json:"pokemon"`
+}
+
+// PokemonSpecies is the species a Pokemon belongs to, which groups together
+// its varieties (e.g. regional forms) and links to its evolution chain.
+type PokemonSpecies struct {
+	ID                   int              `json:"id"`
+	Name                 string           `json:"name"`
+	Order                int              `json:"order"`
+	GenderRate           int              `json:"gender_rate"`
+	CaptureRate          int              `json:"capture_rate"`
+	BaseHappiness        int              `json:"base_happiness"`
+	IsBaby               bool             `json:"is_baby"`
+	IsLegendary          bool             `json:"is_legendary"`
+	IsMythical           bool             `json:"is_mythical"`
+	HatchCounter         int              `json:"hatch_counter"`
+	HasGenderDifferences bool             `json:"has_gender_differences"`
+	FormsSwitchable      bool             `json:"forms_switchable"`
+	GrowthRate           NamedLink        `json:"growth_rate"`
+	PokedexNumbers       []PokedexNumbers `json:"pokedex_numbers"`
+	EggGroups            []NamedLink      `json:"egg_groups"`
+	Color                NamedLink        `json:"color"`
+	Shape                NamedLink        `json:"shape"`
+	EvolvesFromSpecies   *NamedLink       `json:"evolves_from_species"`
+	EvolutionChain       APIResource      `json:"evolution_chain"`
+	Habitat              *NamedLink       `json:"habitat"`
+	Generation           NamedLink        `json:"generation"`
+	Names                []Names          `json:"names"`
+	FlavorTextEntries    []FlavorText     `json:"flavor_text_entries"`
+	Genera               []Genus          `json:"genera"`
+	Varieties            []Varieties      `json:"varieties"`
+
+	// Extra holds unknown fields, see `Config.KeepUnknownFields`.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// MarshalJSON encodes the PokemonSpecies including any unknown fields in `Extra`.
+func (s PokemonSpecies) MarshalJSON() ([]byte, error) {
+	type plain PokemonSpecies
+	return marshalWithExtra(plain(s), s.Extra)
+}
+
+// GetPokemonSpecies returns a single PokemonSpecies from the API.
+//
+//	species, err := sdk.GetPokemonSpecies(ctx, "eevee")
+func (s *SDK) GetPokemonSpecies(ctx context.Context, name string, opts ...CallOption) (*PokemonSpecies, error) {
+	value, _, err := s.GetPokemonSpeciesWithResponse(ctx, name, opts...)
+	return value, err
+}
+
+// GetPokemonSpeciesWithResponse is like `GetPokemonSpecies` but also returns metadata about the
+// response. See `FollowWithResponse`.
+//
+//	species, resp, err := sdk.GetPokemonSpeciesWithResponse(ctx, "eevee")
+func (s *SDK) GetPokemonSpeciesWithResponse(ctx context.Context, name string, opts ...CallOption) (*PokemonSpecies, *Response, error) {
+	return FollowWithResponse[PokemonSpecies](ctx, s, s.baseURL+"/api/v2/pokemon-species/"+name, opts...)
+}
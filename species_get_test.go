@@ -0,0 +1,41 @@
+package pokesdk_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/danielgtaylor/pokesdk"
+)
+
+func TestGetPokemonSpecies(t *testing.T) {
+	ctx := context.Background()
+
+	transport := &mockTransport{}
+	transport.Expect(
+		"https://pokeapi.co/api/v2/pokemon-species/pikachu",
+		http.StatusOK,
+		`{"name":"pikachu","evolves_from_species":{"name":"pichu","url":""},"evolution_chain":{"url":"https://pokeapi.co/api/v2/evolution-chain/10/"},"genera":[{"genus":"Mouse Pokémon","language":{"name":"en"}}]}`,
+	)
+
+	sdk := pokesdk.New(pokesdk.Config{
+		Client: &http.Client{Transport: transport},
+	})
+
+	species, err := sdk.GetPokemonSpecies(ctx, "pikachu")
+	if err != nil {
+		t.Fatalf("failed to get pikachu species: %v", err)
+	}
+
+	if species.EvolvesFromSpecies == nil || species.EvolvesFromSpecies.Name != "pichu" {
+		t.Errorf("expected to evolve from pichu, got %v", species.EvolvesFromSpecies)
+	}
+
+	if species.EvolutionChain.URL != "https://pokeapi.co/api/v2/evolution-chain/10/" {
+		t.Errorf("unexpected evolution chain %s", species.EvolutionChain.URL)
+	}
+
+	if genus := pokesdk.LocalizedGenus(species.Genera); genus != "Mouse Pokémon" {
+		t.Errorf("unexpected genus %s", genus)
+	}
+}
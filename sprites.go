@@ -0,0 +1,378 @@
+package pokesdk
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// SpriteSet is a set of sprite URLs for a single artwork style or game. URLs
+// which are not available are nil, and unknown keys are kept in `Extra`.
+type SpriteSet struct {
+	FrontDefault          *string `json:"front_default,omitempty"`
+	FrontFemale           *string `json:"front_female,omitempty"`
+	FrontShiny            *string `json:"front_shiny,omitempty"`
+	FrontShinyFemale      *string `json:"front_shiny_female,omitempty"`
+	BackDefault           *string `json:"back_default,omitempty"`
+	BackFemale            *string `json:"back_female,omitempty"`
+	BackShiny             *string `json:"back_shiny,omitempty"`
+	BackShinyFemale       *string `json:"back_shiny_female,omitempty"`
+	FrontGray             *string `json:"front_gray,omitempty"`
+	BackGray              *string `json:"back_gray,omitempty"`
+	FrontTransparent      *string `json:"front_transparent,omitempty"`
+	BackTransparent       *string `json:"back_transparent,omitempty"`
+	FrontShinyTransparent *string `json:"front_shiny_transparent,omitempty"`
+	BackShinyTransparent  *string `json:"back_shiny_transparent,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// MarshalJSON encodes the sprites including any unknown keys in `Extra`.
+func (s SpriteSet) MarshalJSON() ([]byte, error) {
+	type plain SpriteSet
+	return marshalSprites(plain(s), s.Extra)
+}
+
+// AnimatedSpriteSet is a sprite set which also has animated sprites.
+type AnimatedSpriteSet struct {
+	SpriteSet
+	Animated SpriteSet `json:"animated"`
+}
+
+// UnmarshalJSON decodes the sprites. Unknown keys are kept in `Extra` when
+// decoding `Sprites`.
+func (s *AnimatedSpriteSet) UnmarshalJSON(data []byte) error {
+	// The embedded set's own methods must not be used to decode the
+	// animated sprites alongside it.
+	type plainSet SpriteSet
+	var v struct {
+		plainSet
+		Animated SpriteSet `json:"animated"`
+	}
+	if err := JSONCodec.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	s.SpriteSet = SpriteSet(v.plainSet)
+	s.Animated = v.Animated
+	return nil
+}
+
+// MarshalJSON encodes the sprites including any unknown keys in `Extra`.
+func (s AnimatedSpriteSet) MarshalJSON() ([]byte, error) {
+	animated, err := marshalNoEscape(s.Animated)
+	if err != nil {
+		return nil, err
+	}
+
+	extra := map[string]json.RawMessage{}
+	if string(animated) != "{}" {
+		extra["animated"] = animated
+	}
+	for k, v := range s.Extra {
+		extra[k] = v
+	}
+
+	type plain SpriteSet
+	return marshalSprites(plain(s.SpriteSet), extra)
+}
+
+// OtherSprites holds sprites in artwork styles which are not tied to a
+// specific game. Styles not modeled here are kept in `Extra`.
+type OtherSprites struct {
+	DreamWorld      SpriteSet                  `json:"dream_world"`
+	Home            SpriteSet                  `json:"home"`
+	OfficialArtwork SpriteSet                  `json:"official-artwork"`
+	Showdown        SpriteSet                  `json:"showdown"`
+	Extra           map[string]json.RawMessage `json:"-"`
+}
+
+// MarshalJSON encodes the sprites including any unknown keys in `Extra`.
+func (s OtherSprites) MarshalJSON() ([]byte, error) {
+	type plain OtherSprites
+	return marshalSprites(plain(s), s.Extra)
+}
+
+// VersionSprites holds game-specific sprites grouped by generation.
+// Generations not modeled here are kept in `Extra`.
+type VersionSprites struct {
+	GenerationI    GenerationISprites         `json:"generation-i"`
+	GenerationII   GenerationIISprites        `json:"generation-ii"`
+	GenerationIII  GenerationIIISprites       `json:"generation-iii"`
+	GenerationIV   GenerationIVSprites        `json:"generation-iv"`
+	GenerationV    GenerationVSprites         `json:"generation-v"`
+	GenerationVI   GenerationVISprites        `json:"generation-vi"`
+	GenerationVII  GenerationVIISprites       `json:"generation-vii"`
+	GenerationVIII GenerationVIIISprites      `json:"generation-viii"`
+	GenerationIX   GenerationIXSprites        `json:"generation-ix"`
+	Extra          map[string]json.RawMessage `json:"-"`
+}
+
+// MarshalJSON encodes the sprites including any unknown keys in `Extra`.
+func (s VersionSprites) MarshalJSON() ([]byte, error) {
+	type plain VersionSprites
+	return marshalSprites(plain(s), s.Extra)
+}
+
+// GenerationISprites holds the sprites used in generation I games. Games not
+// modeled here are kept in `Extra`.
+type GenerationISprites struct {
+	RedBlue SpriteSet                  `json:"red-blue"`
+	Yellow  SpriteSet                  `json:"yellow"`
+	Extra   map[string]json.RawMessage `json:"-"`
+}
+
+// MarshalJSON encodes the sprites including any unknown keys in `Extra`.
+func (s GenerationISprites) MarshalJSON() ([]byte, error) {
+	type plain GenerationISprites
+	return marshalSprites(plain(s), s.Extra)
+}
+
+// GenerationIISprites holds the sprites used in generation II games. Games not
+// modeled here are kept in `Extra`.
+type GenerationIISprites struct {
+	Crystal SpriteSet                  `json:"crystal"`
+	Gold    SpriteSet                  `json:"gold"`
+	Silver  SpriteSet                  `json:"silver"`
+	Extra   map[string]json.RawMessage `json:"-"`
+}
+
+// MarshalJSON encodes the sprites including any unknown keys in `Extra`.
+func (s GenerationIISprites) MarshalJSON() ([]byte, error) {
+	type plain GenerationIISprites
+	return marshalSprites(plain(s), s.Extra)
+}
+
+// GenerationIIISprites holds the sprites used in generation III games. Games not
+// modeled here are kept in `Extra`.
+type GenerationIIISprites struct {
+	Emerald          SpriteSet                  `json:"emerald"`
+	FireredLeafgreen SpriteSet                  `json:"firered-leafgreen"`
+	RubySapphire     SpriteSet                  `json:"ruby-sapphire"`
+	Extra            map[string]json.RawMessage `json:"-"`
+}
+
+// MarshalJSON encodes the sprites including any unknown keys in `Extra`.
+func (s GenerationIIISprites) MarshalJSON() ([]byte, error) {
+	type plain GenerationIIISprites
+	return marshalSprites(plain(s), s.Extra)
+}
+
+// GenerationIVSprites holds the sprites used in generation IV games. Games not
+// modeled here are kept in `Extra`.
+type GenerationIVSprites struct {
+	DiamondPearl        SpriteSet                  `json:"diamond-pearl"`
+	HeartgoldSoulsilver SpriteSet                  `json:"heartgold-soulsilver"`
+	Platinum            SpriteSet                  `json:"platinum"`
+	Extra               map[string]json.RawMessage `json:"-"`
+}
+
+// MarshalJSON encodes the sprites including any unknown keys in `Extra`.
+func (s GenerationIVSprites) MarshalJSON() ([]byte, error) {
+	type plain GenerationIVSprites
+	return marshalSprites(plain(s), s.Extra)
+}
+
+// GenerationVSprites holds the sprites used in generation V games. Games not
+// modeled here are kept in `Extra`.
+type GenerationVSprites struct {
+	BlackWhite AnimatedSpriteSet          `json:"black-white"`
+	Extra      map[string]json.RawMessage `json:"-"`
+}
+
+// MarshalJSON encodes the sprites including any unknown keys in `Extra`.
+func (s GenerationVSprites) MarshalJSON() ([]byte, error) {
+	type plain GenerationVSprites
+	return marshalSprites(plain(s), s.Extra)
+}
+
+// GenerationVISprites holds the sprites used in generation VI games. Games not
+// modeled here are kept in `Extra`.
+type GenerationVISprites struct {
+	OmegarubyAlphasapphire SpriteSet                  `json:"omegaruby-alphasapphire"`
+	XY                     SpriteSet                  `json:"x-y"`
+	Extra                  map[string]json.RawMessage `json:"-"`
+}
+
+// MarshalJSON encodes the sprites including any unknown keys in `Extra`.
+func (s GenerationVISprites) MarshalJSON() ([]byte, error) {
+	type plain GenerationVISprites
+	return marshalSprites(plain(s), s.Extra)
+}
+
+// GenerationVIISprites holds the sprites used in generation VII games. Games not
+// modeled here are kept in `Extra`.
+type GenerationVIISprites struct {
+	Icons             SpriteSet                  `json:"icons"`
+	UltraSunUltraMoon SpriteSet                  `json:"ultra-sun-ultra-moon"`
+	Extra             map[string]json.RawMessage `json:"-"`
+}
+
+// MarshalJSON encodes the sprites including any unknown keys in `Extra`.
+func (s GenerationVIISprites) MarshalJSON() ([]byte, error) {
+	type plain GenerationVIISprites
+	return marshalSprites(plain(s), s.Extra)
+}
+
+// GenerationVIIISprites holds the sprites used in generation VIII games. Games not
+// modeled here are kept in `Extra`.
+type GenerationVIIISprites struct {
+	BrilliantDiamondShiningPearl SpriteSet                  `json:"brilliant-diamond-shining-pearl"`
+	Icons                        SpriteSet                  `json:"icons"`
+	Extra                        map[string]json.RawMessage `json:"-"`
+}
+
+// MarshalJSON encodes the sprites including any unknown keys in `Extra`.
+func (s GenerationVIIISprites) MarshalJSON() ([]byte, error) {
+	type plain GenerationVIIISprites
+	return marshalSprites(plain(s), s.Extra)
+}
+
+// GenerationIXSprites holds the sprites used in generation IX games. Games not
+// modeled here are kept in `Extra`.
+type GenerationIXSprites struct {
+	ScarletViolet SpriteSet                  `json:"scarlet-violet"`
+	Extra         map[string]json.RawMessage `json:"-"`
+}
+
+// MarshalJSON encodes the sprites including any unknown keys in `Extra`.
+func (s GenerationIXSprites) MarshalJSON() ([]byte, error) {
+	type plain GenerationIXSprites
+	return marshalSprites(plain(s), s.Extra)
+}
+
+// Sprites holds all sprite URLs for a Pokemon.
+type Sprites struct {
+	BackDefault      string         `json:"back_default"`
+	BackFemale       string         `json:"back_female"`
+	BackShiny        string         `json:"back_shiny"`
+	BackShinyFemale  string         `json:"back_shiny_female"`
+	FrontDefault     string         `json:"front_default"`
+	FrontFemale      string         `json:"front_female"`
+	FrontShiny       string         `json:"front_shiny"`
+	FrontShinyFemale string         `json:"front_shiny_female"`
+	Other            OtherSprites   `json:"other"`
+	Versions         VersionSprites `json:"versions"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the sprites and keeps unknown keys in `Extra` at
+// every level, including unknown styles, generations and games.
+func (s *Sprites) UnmarshalJSON(data []byte) error {
+	type plain Sprites
+	if err := JSONCodec.Unmarshal(data, (*plain)(s)); err != nil {
+		return err
+	}
+	return fillExtra(JSONCodec, data, s)
+}
+
+// MarshalJSON encodes the sprites including any unknown keys in `Extra`.
+func (s Sprites) MarshalJSON() ([]byte, error) {
+	type plain Sprites
+	return marshalSprites(plain(s), s.Extra)
+}
+
+// SpriteURL is a single available sprite along with the slash-separated path
+// of JSON keys leading to it, e.g. `versions/generation-v/black-white/animated/front_shiny`.
+type SpriteURL struct {
+	Path string
+	URL  string
+}
+
+// Each calls `yield` for every available sprite URL, including those in
+// `Extra` fields, until `yield` returns false.
+//
+//	pika.Sprites.Each(func(s pokesdk.SpriteURL) bool {
+//		fmt.Println(s.Path, s.URL)
+//		return true
+//	})
+func (s *Sprites) Each(yield func(SpriteURL) bool) {
+	walkSprites(reflect.ValueOf(s).Elem(), nil, yield)
+}
+
+// URLs returns all available sprite URLs. See `Each`.
+func (s *Sprites) URLs() []SpriteURL {
+	urls := []SpriteURL{}
+	s.Each(func(u SpriteURL) bool {
+		urls = append(urls, u)
+		return true
+	})
+	return urls
+}
+
+var rawMessageType = reflect.TypeOf(json.RawMessage{})
+
+// marshalSprites works like `marshalWithExtra` but leaves out sprite sets
+// which are empty, so that re-encoding keeps the shape of the original
+// payload instead of listing every modeled style and game.
+func marshalSprites(v any, extra map[string]json.RawMessage) ([]byte, error) {
+	b, err := marshalNoEscape(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var merged map[string]json.RawMessage
+	if err := json.Unmarshal(b, &merged); err != nil {
+		return nil, err
+	}
+	for k, r := range merged {
+		if string(r) == "{}" {
+			delete(merged, k)
+		}
+	}
+	for k, r := range extra {
+		merged[k] = r
+	}
+	return marshalObject(merged)
+}
+
+// walkSprites recursively visits every non-empty URL in a sprite structure.
+// It returns false once `yield` asks to stop.
+func walkSprites(v reflect.Value, path []string, yield func(SpriteURL) bool) bool {
+	switch {
+	case v.Type() == rawMessageType:
+		// Unknown keys may hold either a URL or another set of sprites.
+		var url string
+		if err := json.Unmarshal(v.Bytes(), &url); err == nil {
+			return url == "" || yield(SpriteURL{Path: strings.Join(path, "/"), URL: url})
+		}
+		var nested map[string]json.RawMessage
+		if err := json.Unmarshal(v.Bytes(), &nested); err == nil {
+			return walkSprites(reflect.ValueOf(nested), path, yield)
+		}
+	case v.Kind() == reflect.String:
+		if url := v.String(); url != "" {
+			return yield(SpriteURL{Path: strings.Join(path, "/"), URL: url})
+		}
+	case v.Kind() == reflect.Pointer:
+		if !v.IsNil() {
+			return walkSprites(v.Elem(), path, yield)
+		}
+	case v.Kind() == reflect.Map:
+		keys := make([]string, 0, v.Len())
+		for _, k := range v.MapKeys() {
+			keys = append(keys, k.String())
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if !walkSprites(v.MapIndex(reflect.ValueOf(k)), append(path, k), yield) {
+				return false
+			}
+		}
+	case v.Kind() == reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+			p := path
+			if !f.Anonymous && name != "-" {
+				p = append(path, name)
+			}
+			if !walkSprites(v.Field(i), p, yield) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,181 @@
+package pokesdk_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/danielgtaylor/pokesdk"
+)
+
+const typedSprites = `{
+	"front_default": "https://example.com/25.png",
+	"back_default": null,
+	"other": {
+		"official-artwork": {"front_default": "https://example.com/artwork/25.png", "front_shiny": null},
+		"showdown": {"front_default": "https://example.com/showdown/25.gif"},
+		"hologram": {"front_default": "https://example.com/holo/25.png"}
+	},
+	"versions": {
+		"generation-i": {
+			"red-blue": {"front_gray": "https://example.com/i/gray/25.png", "back_transparent": null}
+		},
+		"generation-v": {
+			"black-white": {
+				"animated": {"front_default": "https://example.com/v/anim/25.gif", "back_shiny": null},
+				"front_default": "https://example.com/v/25.png",
+				"front_sparkle": "https://example.com/v/sparkle/25.png"
+			}
+		},
+		"generation-x": {
+			"future-game": {"front_default": "https://example.com/x/25.png"}
+		}
+	},
+	"sprite_sheet": "https://example.com/sheet.png"
+}`
+
+func TestTypedSprites(t *testing.T) {
+	var sprites pokesdk.Sprites
+	if err := json.Unmarshal([]byte(typedSprites), &sprites); err != nil {
+		t.Fatal(err)
+	}
+
+	if deref(sprites.Other.OfficialArtwork.FrontDefault) != "https://example.com/artwork/25.png" {
+		t.Errorf("unexpected official artwork: %+v", sprites.Other.OfficialArtwork)
+	}
+
+	var hologram pokesdk.SpriteSet
+	if err := json.Unmarshal(sprites.Other.Extra["hologram"], &hologram); err != nil || deref(hologram.FrontDefault) != "https://example.com/holo/25.png" {
+		t.Errorf("expected unknown style in overflow: %s", sprites.Other.Extra)
+	}
+
+	if deref(sprites.Versions.GenerationI.RedBlue.FrontGray) != "https://example.com/i/gray/25.png" {
+		t.Errorf("unexpected gray sprite: %+v", sprites.Versions.GenerationI.RedBlue)
+	}
+
+	bw := sprites.Versions.GenerationV.BlackWhite
+	if deref(bw.Animated.FrontDefault) != "https://example.com/v/anim/25.gif" || deref(bw.FrontDefault) != "https://example.com/v/25.png" {
+		t.Errorf("unexpected black-white sprites: %+v", bw)
+	}
+
+	if _, ok := bw.Extra["front_sparkle"]; !ok || len(bw.Extra) != 1 {
+		t.Errorf("expected unknown sprite key in overflow: %v", bw.Extra)
+	}
+
+	if _, ok := sprites.Versions.Extra["generation-x"]; !ok {
+		t.Errorf("expected unknown generation in overflow: %s", sprites.Versions.Extra)
+	}
+
+	// Missing sets must be usable without nil checks on the set itself.
+	if sprites.Versions.GenerationVIII.Icons.FrontDefault != nil {
+		t.Errorf("expected empty icons")
+	}
+	if sprites.Versions.GenerationI.RedBlue.BackTransparent != nil {
+		t.Errorf("expected null sprite to be nil")
+	}
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func TestSpritesUnknownValues(t *testing.T) {
+	// Unknown keys may hold plain URLs rather than sets of sprites.
+	var pokemon pokesdk.Pokemon
+	data := `{"sprites":{"other":{"new-style":"https://example.com/new.png"},"versions":{"generation-i":{"stadium":"https://example.com/stadium.png"}}}}`
+	if err := json.Unmarshal([]byte(data), &pokemon); err != nil {
+		t.Fatal(err)
+	}
+
+	urls := pokemon.Sprites.URLs()
+	if len(urls) != 2 || urls[0].Path != "other/new-style" || urls[1].Path != "versions/generation-i/stadium" {
+		t.Errorf("unexpected sprites: %v", urls)
+	}
+}
+
+func TestSpritesMarshalShape(t *testing.T) {
+	var sprites pokesdk.Sprites
+	if err := json.Unmarshal([]byte(`{"front_default":"u","other":{"official-artwork":{"front_default":"a","front_shiny":null}}}`), &sprites); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := json.Marshal(sprites)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded map[string]any
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the sets present in the payload are encoded.
+	other := decoded["other"].(map[string]any)
+	if len(other) != 1 || !reflect.DeepEqual(other["official-artwork"], map[string]any{"front_default": "a"}) {
+		t.Errorf("unexpected other sprites: %s", b)
+	}
+	if _, ok := decoded["versions"]; ok {
+		t.Errorf("expected no versions: %s", b)
+	}
+}
+
+func TestSpritesRoundTrip(t *testing.T) {
+	var sprites pokesdk.Sprites
+	if err := json.Unmarshal([]byte(typedSprites), &sprites); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := json.Marshal(sprites)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded pokesdk.Sprites
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(sprites.URLs(), decoded.URLs()) {
+		t.Errorf("expected sprites to survive a round trip:\n%v\n%v", sprites.URLs(), decoded.URLs())
+	}
+}
+
+func TestSpritesEach(t *testing.T) {
+	var sprites pokesdk.Sprites
+	if err := json.Unmarshal([]byte(typedSprites), &sprites); err != nil {
+		t.Fatal(err)
+	}
+
+	paths := []string{}
+	for _, u := range sprites.URLs() {
+		paths = append(paths, u.Path)
+	}
+
+	expected := []string{
+		"front_default",
+		"other/official-artwork/front_default",
+		"other/showdown/front_default",
+		"other/hologram/front_default",
+		"versions/generation-i/red-blue/front_gray",
+		"versions/generation-v/black-white/front_default",
+		"versions/generation-v/black-white/front_sparkle",
+		"versions/generation-v/black-white/animated/front_default",
+		"versions/generation-x/future-game/front_default",
+		"sprite_sheet",
+	}
+	if !reflect.DeepEqual(paths, expected) {
+		t.Errorf("unexpected paths: %v", paths)
+	}
+
+	count := 0
+	sprites.Each(func(pokesdk.SpriteURL) bool {
+		count++
+		return count < 3
+	})
+	if count != 3 {
+		t.Errorf("expected iteration to stop after 3, got %d", count)
+	}
+}
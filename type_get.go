@@ -0,0 +1,64 @@
+package pokesdk
+
+import (
+	"context"
+	"encoding/json"
+)
+
+type DamageRelations struct {
+	DoubleDamageFrom []TypeLink `json:"double_damage_from"`
+	DoubleDamageTo   []TypeLink `json:"double_damage_to"`
+	HalfDamageFrom   []TypeLink `json:"half_damage_from"`
+	HalfDamageTo     []TypeLink `json:"half_damage_to"`
+	NoDamageFrom     []TypeLink `json:"no_damage_from"`
+	NoDamageTo       []TypeLink `json:"no_damage_to"`
+}
+
+type PastDamageRelations struct {
+	Generation      NamedLink       `json:"generation"`
+	DamageRelations DamageRelations `json:"damage_relations"`
+}
+
+type TypePokemon struct {
+	Slot    int       `json:"slot"`
+	Pokemon NamedLink `json:"pokemon"`
+}
+
+// Type is a single elemental type, such as `electric`, along with its damage
+// relations to other types and the Pokemon and moves that have it.
+type Type struct {
+	ID                  int                   `json:"id"`
+	Name                TypeName              `json:"name"`
+	DamageRelations     DamageRelations       `json:"damage_relations"`
+	PastDamageRelations []PastDamageRelations `json:"past_damage_relations"`
+	Generation          NamedLink             `json:"generation"`
+	MoveDamageClass     NamedLink             `json:"move_damage_class"`
+	Names               []Names               `json:"names"`
+	Pokemon             []TypePokemon         `json:"pokemon"`
+	Moves               []NamedLink           `json:"moves"`
+
+	// Extra holds unknown fields, see `Config.KeepUnknownFields`.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// MarshalJSON encodes the Type including any unknown fields in `Extra`.
+func (t Type) MarshalJSON() ([]byte, error) {
+	type plain Type
+	return marshalWithExtra(plain(t), t.Extra)
+}
+
+// GetType returns a single Type from the API.
+//
+//	electric, err := sdk.GetType(ctx, "electric")
+func (s *SDK) GetType(ctx context.Context, name string, opts ...CallOption) (*Type, error) {
+	value, _, err := s.GetTypeWithResponse(ctx, name, opts...)
+	return value, err
+}
+
+// GetTypeWithResponse is like `GetType` but also returns metadata about the
+// response. See `FollowWithResponse`.
+//
+//	electric, resp, err := sdk.GetTypeWithResponse(ctx, "electric")
+func (s *SDK) GetTypeWithResponse(ctx context.Context, name string, opts ...CallOption) (*Type, *Response, error) {
+	return FollowWithResponse[Type](ctx, s, s.baseURL+"/api/v2/type/"+name, opts...)
+}
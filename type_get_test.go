@@ -0,0 +1,37 @@
+package pokesdk_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/danielgtaylor/pokesdk"
+)
+
+func TestGetType(t *testing.T) {
+	ctx := context.Background()
+
+	transport := &mockTransport{}
+	transport.Expect(
+		"https://pokeapi.co/api/v2/type/electric",
+		http.StatusOK,
+		`{"name":"electric","damage_relations":{"double_damage_to":[{"name":"water","url":""},{"name":"flying","url":""}],"no_damage_to":[{"name":"ground","url":""}]}}`,
+	)
+
+	sdk := pokesdk.New(pokesdk.Config{
+		Client: &http.Client{Transport: transport},
+	})
+
+	electric, err := sdk.GetType(ctx, "electric")
+	if err != nil {
+		t.Fatalf("failed to get electric: %v", err)
+	}
+
+	if electric.Name != pokesdk.TypeElectric {
+		t.Errorf("expected electric, got %s", electric.Name)
+	}
+
+	if len(electric.DamageRelations.DoubleDamageTo) != 2 || electric.DamageRelations.NoDamageTo[0].Name != pokesdk.TypeGround {
+		t.Errorf("unexpected damage relations: %+v", electric.DamageRelations)
+	}
+}
@@ -0,0 +1,15 @@
+package pokesdk
+
+// ListTypes returns a paginator for listing Types in the API. You can manually
+// iterate over pages via `Next(ctx)` or use the `All(ctx)` method to get a
+// channel of all results.
+//
+//	for result := range sdk.ListTypes().All(ctx) {
+//		if result.Error != nil {
+//			return fmt.Errorf("failed to list types: %w", result.Error)
+//		}
+//		fmt.Printf("Type: %s\n", result.Value.Name)
+//	}
+func (s *SDK) ListTypes(opts ...CallOption) *Paginator[NamedLink] {
+	return newPaginator[NamedLink](s, s.baseURL+"/api/v2/type", opts)
+}
@@ -0,0 +1,44 @@
+package pokesdk_test
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/danielgtaylor/pokesdk"
+)
+
+const listTypePage = `{
+	"count": 3,
+	"next": null,
+	"previous": null,
+	"results": [
+		{"name": "normal", "url": "https://pokeapi.co/api/v2/type/1/"},
+		{"name": "fighting", "url": "https://pokeapi.co/api/v2/type/2/"},
+		{"name": "flying", "url": "https://pokeapi.co/api/v2/type/3/"}
+	]
+}`
+
+func TestListTypes(t *testing.T) {
+	ctx := context.Background()
+
+	transport := &mockTransport{}
+	transport.Expect("https://pokeapi.co/api/v2/type", http.StatusOK, listTypePage)
+
+	sdk := pokesdk.New(pokesdk.Config{
+		Client: &http.Client{Transport: transport},
+	})
+
+	names := []string{}
+	for result := range sdk.ListTypes().All(ctx) {
+		if result.Error != nil {
+			t.Fatalf("failed to list types: %v", result.Error)
+		}
+		names = append(names, result.Value.Name)
+	}
+
+	if !reflect.DeepEqual(names, []string{"normal", "fighting", "flying"}) {
+		t.Errorf("unexpected names: %v", names)
+	}
+}
@@ -0,0 +1,204 @@
+package pokesdk
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// DisallowedURLError is returned when `Follow` or a paginator is asked to
+// request a URL that is not on the `BaseURL` host or in `AllowedHosts`, or
+// which does not use HTTP(S).
+var DisallowedURLError = errors.New("disallowed URL")
+
+// PokeAPIBaseURL is the base URL of the public Pokemon API.
+const PokeAPIBaseURL = "https://pokeapi.co"
+
+// Rewrite replaces the `From` prefix of a URL with `To`, for example to send
+// links to the public API to a self-hosted mirror instead. `From` only
+// matches whole path segments, so `https://pokeapi.co` does not match
+// `https://pokeapi.com`.
+type Rewrite struct {
+	From string
+	To   string
+}
+
+// apply returns the rewritten URL and whether the rule matched.
+func (r Rewrite) apply(u string) (string, bool) {
+	rest, ok := cutBase(u, r.From)
+	if !ok {
+		return u, false
+	}
+	return r.To + rest, true
+}
+
+// RewriteURL applies the first matching rule from `Config.Rewrites` to the
+// URL. It is applied automatically wherever the SDK follows URLs from
+// responses, and by `ResolveURL`.
+func (s *SDK) RewriteURL(u string) string {
+	for _, r := range s.rewrites {
+		if rewritten, ok := r.apply(u); ok {
+			return rewritten
+		}
+	}
+	return u
+}
+
+// RewriteURLs applies `Config.Rewrites` to every URL in a decoded value, such
+// as the `NamedLink.URL` fields of a `*Pokemon`, so they can be handed to
+// consumers which don't use the SDK. The value must be a pointer. Unknown
+// fields in `Extra` maps are rewritten as well.
+//
+//	pika, err := sdk.GetPokemon(ctx, "pikachu")
+//	sdk.RewriteURLs(pika)
+func (s *SDK) RewriteURLs(v any) {
+	if len(s.rewrites) > 0 {
+		s.rewriteValue(reflect.ValueOf(v))
+	}
+}
+
+func (s *SDK) rewriteValue(v reflect.Value) {
+	switch {
+	case v.Type() == rawMessageType:
+		if v.Len() == 0 || !v.CanSet() {
+			return
+		}
+		var decoded any
+		if err := json.Unmarshal(v.Bytes(), &decoded); err != nil {
+			return
+		}
+		if rewritten, changed := s.rewriteAny(decoded); changed {
+			if b, err := json.Marshal(rewritten); err == nil {
+				v.SetBytes(b)
+			}
+		}
+	case v.Kind() == reflect.String:
+		if v.CanSet() {
+			v.SetString(s.RewriteURL(v.String()))
+		}
+	case v.Kind() == reflect.Pointer:
+		if !v.IsNil() {
+			s.rewriteValue(v.Elem())
+		}
+	case v.Kind() == reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				s.rewriteValue(v.Field(i))
+			}
+		}
+	case v.Kind() == reflect.Slice, v.Kind() == reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			s.rewriteValue(v.Index(i))
+		}
+	case v.Kind() == reflect.Map:
+		// Map values are not addressable, so rewrite a copy and store it.
+		iter := v.MapRange()
+		for iter.Next() {
+			elem := reflect.New(iter.Value().Type()).Elem()
+			elem.Set(iter.Value())
+			s.rewriteValue(elem)
+			v.SetMapIndex(iter.Key(), elem)
+		}
+	}
+}
+
+// rewriteAny rewrites URLs in generic decoded JSON.
+func (s *SDK) rewriteAny(v any) (any, bool) {
+	changed := false
+	switch v := v.(type) {
+	case string:
+		rewritten := s.RewriteURL(v)
+		return rewritten, rewritten != v
+	case []any:
+		for i, item := range v {
+			var c bool
+			v[i], c = s.rewriteAny(item)
+			changed = changed || c
+		}
+	case map[string]any:
+		for k, item := range v {
+			var c bool
+			v[k], c = s.rewriteAny(item)
+			changed = changed || c
+		}
+	}
+	return v, changed
+}
+
+// ResolveURL rewrites a URL from a caller or a response, resolves it against
+// the base URL, and checks that it is safe to follow, returning a
+// `DisallowedURLError` otherwise. It is applied automatically by `Follow` and
+// paginators, and should be used before passing server-provided URLs to
+// `Request`.
+func (s *SDK) ResolveURL(raw string) (string, error) {
+	if s.base == nil {
+		return "", fmt.Errorf("%w: invalid base URL %q", DisallowedURLError, s.baseURL)
+	}
+
+	// An empty URL would resolve to the base URL, e.g. when calling `Next`
+	// on a paginator after the last page.
+	if raw == "" {
+		return "", fmt.Errorf("%w: empty URL", DisallowedURLError)
+	}
+
+	raw = s.RewriteURL(raw)
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", DisallowedURLError, err)
+	}
+	u = s.base.ResolveReference(u)
+	if err := s.checkURL(u, ""); err != nil {
+		return "", err
+	}
+
+	return u.String(), nil
+}
+
+// checkURL returns a `DisallowedURLError` unless the absolute URL uses HTTP(S)
+// and is on the base URL host, in `Config.AllowedHosts` or on the `also` host.
+func (s *SDK) checkURL(u *url.URL, also string) error {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("%w: unsupported scheme in %q", DisallowedURLError, u)
+	}
+
+	host := strings.ToLower(u.Host)
+	allowed := host == strings.ToLower(s.base.Host) || s.allowedHosts[host] || s.allowedHosts["*"] ||
+		(also != "" && host == strings.ToLower(also))
+	if !allowed {
+		return fmt.Errorf("%w: host %q is not allowed", DisallowedURLError, u.Host)
+	}
+
+	return nil
+}
+
+// checkRedirects returns a copy of the client which also checks every
+// redirect target with `checkURL`, so that a server cannot send requests to a
+// host that `ResolveURL` would refuse. Redirects may also stay on the host of
+// the original request, e.g. for assets from `assets.DefaultHosts`. The
+// client's own `CheckRedirect` still applies afterwards.
+func (s *SDK) checkRedirects(client *http.Client) *http.Client {
+	next := client.CheckRedirect
+	c := *client
+	c.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if s.base == nil {
+			return fmt.Errorf("%w: invalid base URL %q", DisallowedURLError, s.baseURL)
+		}
+		if err := s.checkURL(req.URL, via[0].URL.Host); err != nil {
+			return err
+		}
+		if next != nil {
+			return next(req, via)
+		}
+		// The same limit as the default policy of `http.Client`.
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+		return nil
+	}
+	return &c
+}
@@ -0,0 +1,291 @@
+package pokesdk_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/danielgtaylor/pokesdk"
+)
+
+func TestFollowDisallowedURL(t *testing.T) {
+	ctx := context.Background()
+
+	sdk := pokesdk.New(pokesdk.Config{
+		Client:       &http.Client{Transport: &mockTransport{}},
+		AllowedHosts: []string{"cdn.example.com"},
+	})
+
+	for _, url := range []string{
+		"https://internal.example.com/admin",
+		"http://169.254.169.254/latest/meta-data",
+		"file:///etc/passwd",
+		"ftp://pokeapi.co/api/v2/pokemon/pikachu",
+		"//evil.example.com/api/v2/pokemon/pikachu",
+	} {
+		_, err := pokesdk.Follow[pokesdk.Pokemon](ctx, sdk, url)
+		if !errors.Is(err, pokesdk.DisallowedURLError) {
+			t.Errorf("expected %s to be disallowed, got %v", url, err)
+		}
+	}
+}
+
+func TestFollowAllowedURL(t *testing.T) {
+	ctx := context.Background()
+
+	transport := &mockTransport{}
+	transport.Expect("https://pokeapi.co/api/v2/pokemon/pikachu", http.StatusOK, `{"name":"pikachu"}`)
+	transport.Expect("https://CDN.example.com/pokemon/pikachu", http.StatusOK, `{"name":"pikachu"}`)
+
+	sdk := pokesdk.New(pokesdk.Config{
+		Client:       &http.Client{Transport: transport},
+		AllowedHosts: []string{"cdn.example.com"},
+	})
+
+	// Relative URLs are resolved against the base URL.
+	for _, url := range []string{"/api/v2/pokemon/pikachu", "https://CDN.example.com/pokemon/pikachu"} {
+		if _, err := pokesdk.Follow[pokesdk.Pokemon](ctx, sdk, url); err != nil {
+			t.Errorf("unexpected error for %s: %v", url, err)
+		}
+	}
+}
+
+func TestRewritePokeAPI(t *testing.T) {
+	ctx := context.Background()
+
+	transport := &mockTransport{}
+	transport.Expect("https://mirror.example.com/api/v2/pokemon", http.StatusOK, `{
+		"count": 2,
+		"next": "https://pokeapi.co/api/v2/pokemon?offset=1&limit=1",
+		"results": [{"name": "bulbasaur", "url": "https://pokeapi.co/api/v2/pokemon/1/"}]
+	}`)
+	transport.Expect("https://mirror.example.com/api/v2/pokemon?offset=1&limit=1", http.StatusOK, `{
+		"count": 2,
+		"results": [{"name": "ivysaur", "url": "https://pokeapi.co/api/v2/pokemon/2/"}]
+	}`)
+
+	sdk := pokesdk.New(pokesdk.Config{
+		BaseURL:        "https://mirror.example.com",
+		Client:         &http.Client{Transport: transport},
+		RewritePokeAPI: true,
+	})
+
+	for result := range sdk.ListPokemon().All(ctx) {
+		if result.Error != nil {
+			t.Fatalf("unexpected error: %v", result.Error)
+		}
+	}
+
+	if len(transport.requests) != 2 {
+		t.Errorf("expected 2 requests, got %d", len(transport.requests))
+	}
+}
+
+func TestPaginatorDisallowedNext(t *testing.T) {
+	ctx := context.Background()
+
+	transport := &mockTransport{}
+	transport.Expect("https://pokeapi.co/api/v2/pokemon", http.StatusOK, `{
+		"count": 2,
+		"next": "https://evil.example.com/api/v2/pokemon?offset=1&limit=1",
+		"results": [{"name": "bulbasaur", "url": "https://pokeapi.co/api/v2/pokemon/1/"}]
+	}`)
+
+	sdk := pokesdk.New(pokesdk.Config{
+		Client: &http.Client{Transport: transport},
+	})
+
+	var err error
+	for result := range sdk.ListPokemon().All(ctx) {
+		err = result.Error
+	}
+
+	if !errors.Is(err, pokesdk.DisallowedURLError) {
+		t.Errorf("expected disallowed URL error, got %v", err)
+	}
+}
+
+func TestPaginatorNextAfterLastPage(t *testing.T) {
+	ctx := context.Background()
+
+	transport := &mockTransport{}
+	transport.Expect("https://pokeapi.co/api/v2/pokemon", http.StatusOK, `{
+		"count": 1,
+		"next": null,
+		"results": [{"name": "bulbasaur", "url": "https://pokeapi.co/api/v2/pokemon/1/"}]
+	}`)
+
+	sdk := pokesdk.New(pokesdk.Config{
+		Client: &http.Client{Transport: transport},
+	})
+
+	p := sdk.ListPokemon()
+	if _, err := p.Next(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// There is no next page, so nothing must be requested, especially not
+	// the base URL.
+	if _, err := p.Next(ctx); !errors.Is(err, pokesdk.DisallowedURLError) {
+		t.Errorf("expected disallowed URL error, got %v", err)
+	}
+	if len(transport.requests) != 1 {
+		t.Errorf("expected 1 request, got %d", len(transport.requests))
+	}
+}
+
+func TestRewriteURL(t *testing.T) {
+	sdk := pokesdk.New(pokesdk.Config{
+		BaseURL: "https://mirror.example.com/pokeapi",
+		Rewrites: []pokesdk.Rewrite{
+			{From: "https://raw.githubusercontent.com/PokeAPI/sprites/master/", To: "https://cdn.example.com/sprites/"},
+		},
+		RewritePokeAPI: true,
+	})
+
+	for input, expected := range map[string]string{
+		"https://pokeapi.co":                       "https://mirror.example.com/pokeapi",
+		"https://pokeapi.co/api/v2/pokemon/25/":    "https://mirror.example.com/pokeapi/api/v2/pokemon/25/",
+		"https://pokeapi.co?x=1":                   "https://mirror.example.com/pokeapi?x=1",
+		"https://pokeapi.com/api/v2/pokemon/25/":   "https://pokeapi.com/api/v2/pokemon/25/",
+		"https://pokeapi.co.evil.example.com/api/": "https://pokeapi.co.evil.example.com/api/",
+		"https://raw.githubusercontent.com/PokeAPI/sprites/master/sprites/pokemon/25.png": "https://cdn.example.com/sprites/sprites/pokemon/25.png",
+	} {
+		if actual := sdk.RewriteURL(input); actual != expected {
+			t.Errorf("expected %s to rewrite to %s, got %s", input, expected, actual)
+		}
+	}
+}
+
+func TestRewriteResponses(t *testing.T) {
+	ctx := context.Background()
+
+	transport := &mockTransport{}
+	transport.Expect("https://mirror.example.com/api/v2/pokemon/pikachu", http.StatusOK, `{
+		"name": "pikachu",
+		"species": {"name": "pikachu", "url": "https://pokeapi.co/api/v2/pokemon-species/25/"},
+		"types": [{"slot": 1, "type": {"name": "electric", "url": "https://pokeapi.co/api/v2/type/13/"}}],
+		"future_links": {"form": "https://pokeapi.co/api/v2/pokemon-form/25/"}
+	}`)
+
+	sdk := pokesdk.New(pokesdk.Config{
+		BaseURL:           "https://mirror.example.com",
+		Client:            &http.Client{Transport: transport},
+		RewritePokeAPI:    true,
+		RewriteResponses:  true,
+		KeepUnknownFields: true,
+	})
+
+	pika, err := sdk.GetPokemon(ctx, "pikachu")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if pika.Species.URL != "https://mirror.example.com/api/v2/pokemon-species/25/" {
+		t.Errorf("expected species URL to be rewritten, got %s", pika.Species.URL)
+	}
+
+	if pika.Types[0].Type.URL != "https://mirror.example.com/api/v2/type/13/" {
+		t.Errorf("expected type URL to be rewritten, got %s", pika.Types[0].Type.URL)
+	}
+
+	if extra := string(pika.Extra["future_links"]); extra != `{"form":"https://mirror.example.com/api/v2/pokemon-form/25/"}` {
+		t.Errorf("expected unknown fields to be rewritten, got %s", extra)
+	}
+}
+
+func TestRedirectDisallowedURL(t *testing.T) {
+	ctx := context.Background()
+
+	for name, location := range map[string]string{
+		"host":   "https://evil.example.com/api/v2/pokemon/pikachu",
+		"scheme": "ftp://pokeapi.co/api/v2/pokemon/pikachu",
+	} {
+		t.Run(name, func(t *testing.T) {
+			transport := &mockTransport{}
+			transport.ExpectResponse("https://pokeapi.co/api/v2/pokemon/pikachu", &http.Response{
+				StatusCode: http.StatusFound,
+				Header:     http.Header{"Location": []string{location}},
+				Body:       io.NopCloser(strings.NewReader("")),
+			})
+
+			sdk := pokesdk.New(pokesdk.Config{
+				Client: &http.Client{Transport: transport},
+			})
+
+			if _, err := sdk.GetPokemon(ctx, "pikachu"); !errors.Is(err, pokesdk.DisallowedURLError) {
+				t.Errorf("expected disallowed URL error, got %v", err)
+			}
+			if len(transport.requests) != 1 {
+				t.Errorf("expected 1 request, got %d", len(transport.requests))
+			}
+		})
+	}
+}
+
+func TestRedirectAllowedHost(t *testing.T) {
+	ctx := context.Background()
+
+	transport := &mockTransport{}
+	transport.ExpectResponse("https://pokeapi.co/api/v2/pokemon/pikachu", &http.Response{
+		StatusCode: http.StatusMovedPermanently,
+		Header:     http.Header{"Location": []string{"https://cdn.example.com/pokemon/25"}},
+		Body:       io.NopCloser(strings.NewReader("")),
+	})
+	transport.Expect("https://cdn.example.com/pokemon/25", http.StatusOK, `{"name": "pikachu"}`)
+
+	redirects := 0
+	sdk := pokesdk.New(pokesdk.Config{
+		Client: &http.Client{
+			Transport: transport,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				redirects++
+				return nil
+			},
+		},
+		AllowedHosts: []string{"cdn.example.com"},
+	})
+
+	pika, err := sdk.GetPokemon(ctx, "pikachu")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pika.Name != "pikachu" {
+		t.Errorf("expected pikachu, got %s", pika.Name)
+	}
+
+	// The client's own redirect policy is still used.
+	if redirects != 1 {
+		t.Errorf("expected 1 redirect check, got %d", redirects)
+	}
+}
+
+func TestRedirectSameHost(t *testing.T) {
+	ctx := context.Background()
+
+	// Hosts the caller requests directly, such as assets, may redirect within
+	// the same host.
+	transport := &mockTransport{}
+	transport.ExpectResponse("https://assets.example.com/a.png", &http.Response{
+		StatusCode: http.StatusFound,
+		Header:     http.Header{"Location": []string{"/b.png"}},
+		Body:       io.NopCloser(strings.NewReader("")),
+	})
+	transport.Expect("https://assets.example.com/b.png", http.StatusOK, "png")
+
+	sdk := pokesdk.New(pokesdk.Config{
+		Client: &http.Client{Transport: transport},
+	})
+
+	resp, err := sdk.Request(ctx, http.MethodGet, "https://assets.example.com/a.png", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected 200, got %d", resp.StatusCode)
+	}
+}
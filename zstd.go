@@ -0,0 +1,955 @@
+package pokesdk
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/bits"
+)
+
+// ZstdDecompressor decompresses `zstd` encoded responses as described in
+// RFC 8878. It is registered by default. Frames which need a dictionary are
+// not supported.
+func ZstdDecompressor(r io.Reader) (io.ReadCloser, error) {
+	d := &zstdReader{r: bufio.NewReader(r)}
+	switch err := d.readFrameHeader(true); err {
+	case nil:
+	case io.EOF:
+		// The stream only had skippable frames.
+		d.err = err
+	default:
+		return nil, err
+	}
+	return d, nil
+}
+
+const (
+	zstdMagic = 0xfd2fb528
+
+	// zstdMaxWindow is the largest window which is accepted, which matches
+	// the default limit of the reference decoder.
+	zstdMaxWindow = 1 << 27
+
+	zstdMaxBlock = 128 << 10
+)
+
+func zstdError(what string) error {
+	return errors.New("invalid zstd data: " + what)
+}
+
+// zstdReader decodes a zstd stream.
+type zstdReader struct {
+	r   *bufio.Reader
+	err error
+
+	// hist holds the output of the current frame, of which the last `window`
+	// bytes are kept for matches and `hist[out:]` is yet to be read.
+	hist []byte
+	out  int
+
+	// Frame state.
+	window    int
+	blockMax  int
+	size      int64
+	written   int64
+	checksum  bool
+	hash      xxh64
+	frameDone bool
+
+	block    []byte
+	literals []byte
+	litBuf   []byte
+	huffman  zstdHuffman
+	rep      [3]int
+
+	// tables are the literal length, offset and match length tables in use,
+	// which point to either the predefined tables or to `own`.
+	tables [3]*zstdFSE
+	own    [3]zstdFSE
+}
+
+func (d *zstdReader) Read(p []byte) (int, error) {
+	for d.out == len(d.hist) {
+		if d.err != nil {
+			return 0, d.err
+		}
+		d.err = d.readBlock()
+	}
+	n := copy(p, d.hist[d.out:])
+	d.out += n
+	return n, nil
+}
+
+func (d *zstdReader) Close() error {
+	return nil
+}
+
+// unexpected turns running out of input into `io.ErrUnexpectedEOF`.
+func unexpected(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// readFrameHeader skips any skippable frames and reads the header of the
+// next frame. It returns `io.EOF` if the stream ends before a frame starts,
+// unless this is the first frame.
+func (d *zstdReader) readFrameHeader(first bool) error {
+	var buf [8]byte
+	for {
+		n, err := io.ReadFull(d.r, buf[:4])
+		if n == 0 && err == io.EOF && !first {
+			return io.EOF
+		}
+		if err != nil {
+			return unexpected(err)
+		}
+
+		magic := binary.LittleEndian.Uint32(buf[:])
+		if magic&^0xf == 0x184d2a50 {
+			if _, err := io.ReadFull(d.r, buf[:4]); err != nil {
+				return unexpected(err)
+			}
+			size := int(binary.LittleEndian.Uint32(buf[:]))
+			if _, err := d.r.Discard(size); err != nil {
+				return unexpected(err)
+			}
+			first = false
+			continue
+		}
+		if magic != zstdMagic {
+			return zstdError("unknown frame")
+		}
+		break
+	}
+
+	desc, err := d.r.ReadByte()
+	if err != nil {
+		return unexpected(err)
+	}
+	if desc&8 != 0 {
+		return zstdError("reserved bit set")
+	}
+	single := desc&0x20 != 0
+	d.checksum = desc&4 != 0
+
+	window := uint64(0)
+	if !single {
+		w, err := d.r.ReadByte()
+		if err != nil {
+			return unexpected(err)
+		}
+		base := uint64(1) << (10 + w>>3)
+		window = base + base/8*uint64(w&7)
+	}
+
+	if n := [4]int{0, 1, 2, 4}[desc&3]; n > 0 {
+		clear(buf[:])
+		if _, err := io.ReadFull(d.r, buf[:n]); err != nil {
+			return unexpected(err)
+		}
+		if binary.LittleEndian.Uint32(buf[:4]) != 0 {
+			return zstdError("dictionaries are not supported")
+		}
+	}
+
+	d.size = -1
+	n := [4]int{0, 2, 4, 8}[desc>>6]
+	if n == 0 && single {
+		n = 1
+	}
+	if n > 0 {
+		clear(buf[:])
+		if _, err := io.ReadFull(d.r, buf[:n]); err != nil {
+			return unexpected(err)
+		}
+		size := binary.LittleEndian.Uint64(buf[:])
+		if n == 2 {
+			size += 256
+		}
+		if single {
+			window = size
+		}
+		if size > 1<<62 {
+			return zstdError("frame too large")
+		}
+		d.size = int64(size)
+	}
+	if window > zstdMaxWindow {
+		return zstdError("window too large")
+	}
+
+	d.window = int(window)
+	d.blockMax = min(d.window, zstdMaxBlock)
+	d.written = 0
+	d.hash.reset()
+	d.frameDone = false
+	d.huffman.maxBits = 0
+	d.tables = [3]*zstdFSE{}
+	d.rep = [3]int{1, 4, 8}
+
+	// Matches never refer to previous frames.
+	d.hist = d.hist[:0]
+	d.out = 0
+	return nil
+}
+
+// readBlock reads and decodes the next block, moving on to the next frame if
+// needed.
+func (d *zstdReader) readBlock() error {
+	if d.frameDone {
+		if err := d.readFrameHeader(false); err != nil {
+			return err
+		}
+	}
+	if len(d.hist) > 2*d.window+zstdMaxBlock {
+		n := copy(d.hist, d.hist[len(d.hist)-d.window:])
+		d.hist = d.hist[:n]
+		d.out = n
+	}
+
+	var buf [4]byte
+	if _, err := io.ReadFull(d.r, buf[:3]); err != nil {
+		return unexpected(err)
+	}
+	header := int(binary.LittleEndian.Uint32(buf[:]))
+	last := header&1 == 1
+	size := header >> 3
+	if size > d.blockMax {
+		return zstdError("block too large")
+	}
+
+	start := len(d.hist)
+	switch header >> 1 & 3 {
+	case 0:
+		d.hist = append(d.hist, make([]byte, size)...)
+		if _, err := io.ReadFull(d.r, d.hist[start:]); err != nil {
+			return unexpected(err)
+		}
+	case 1:
+		c, err := d.r.ReadByte()
+		if err != nil {
+			return unexpected(err)
+		}
+		for i := 0; i < size; i++ {
+			d.hist = append(d.hist, c)
+		}
+	case 2:
+		if cap(d.block) < size {
+			d.block = make([]byte, size)
+		}
+		d.block = d.block[:size]
+		if _, err := io.ReadFull(d.r, d.block); err != nil {
+			return unexpected(err)
+		}
+		if err := d.decodeBlock(d.block); err != nil {
+			return err
+		}
+	default:
+		return zstdError("reserved block type")
+	}
+
+	d.written += int64(len(d.hist) - start)
+	if d.size >= 0 && d.written > d.size {
+		return zstdError("frame larger than its content size")
+	}
+	if d.checksum {
+		d.hash.write(d.hist[start:])
+	}
+	if !last {
+		return nil
+	}
+
+	d.frameDone = true
+	if d.size >= 0 && d.written != d.size {
+		return zstdError("frame smaller than its content size")
+	}
+	if d.checksum {
+		if _, err := io.ReadFull(d.r, buf[:4]); err != nil {
+			return unexpected(err)
+		}
+		if binary.LittleEndian.Uint32(buf[:]) != uint32(d.hash.sum()) {
+			return zstdError("checksum mismatch")
+		}
+	}
+	return nil
+}
+
+// decodeBlock decodes a compressed block.
+func (d *zstdReader) decodeBlock(data []byte) error {
+	n, err := d.readLiterals(data)
+	if err != nil {
+		return err
+	}
+	return d.readSequences(data[n:])
+}
+
+// readLiterals reads the literals section of a block into `literals` and
+// returns its size.
+func (d *zstdReader) readLiterals(data []byte) (int, error) {
+	if len(data) == 0 {
+		return 0, zstdError("missing literals")
+	}
+	kind, format := data[0]&3, data[0]>>2&3
+
+	if kind < 2 {
+		// Raw or RLE literals.
+		size, n := int(data[0]>>3), 1
+		switch format {
+		case 1:
+			n = 2
+		case 3:
+			n = 3
+		}
+		if len(data) < n {
+			return 0, zstdError("truncated literals")
+		}
+		if n > 1 {
+			size = int(data[0] >> 4)
+			for i := 1; i < n; i++ {
+				size |= int(data[i]) << (8*i - 4)
+			}
+		}
+		if size > d.blockMax {
+			return 0, zstdError("too many literals")
+		}
+
+		if kind == 0 {
+			if len(data) < n+size {
+				return 0, zstdError("truncated literals")
+			}
+			d.literals = data[n : n+size]
+			return n + size, nil
+		}
+		if len(data) < n+1 {
+			return 0, zstdError("truncated literals")
+		}
+		d.literals = d.literalBuffer(size)
+		for i := range d.literals {
+			d.literals[i] = data[n]
+		}
+		return n + 1, nil
+	}
+
+	// Huffman coded literals, with either one or four streams.
+	n, sizeBits, streams := [4]int{3, 3, 4, 5}[format], uint([4]int{10, 10, 14, 18}[format]), 4
+	if format == 0 {
+		streams = 1
+	}
+	if len(data) < n {
+		return 0, zstdError("truncated literals")
+	}
+	var header uint64
+	for i := n - 1; i >= 0; i-- {
+		header = header<<8 | uint64(data[i])
+	}
+	mask := uint64(1)<<sizeBits - 1
+	size := int(header >> 4 & mask)
+	compressed := int(header >> (4 + sizeBits) & mask)
+	if size > d.blockMax {
+		return 0, zstdError("too many literals")
+	}
+	if len(data) < n+compressed {
+		return 0, zstdError("truncated literals")
+	}
+
+	src := data[n : n+compressed]
+	if kind == 2 {
+		k, err := d.huffman.read(src)
+		if err != nil {
+			return 0, err
+		}
+		src = src[k:]
+	} else if d.huffman.maxBits == 0 {
+		return 0, zstdError("missing Huffman table")
+	}
+
+	d.literals = d.literalBuffer(size)
+	if streams == 1 {
+		return n + compressed, d.huffman.decode(d.literals, src)
+	}
+
+	if len(src) < 6 {
+		return 0, zstdError("truncated literals")
+	}
+	segment := (size + 3) / 4
+	if size < 3*segment {
+		return 0, zstdError("too few literals for four streams")
+	}
+	jumps, src := src[:6], src[6:]
+	for i := 0; i < 4; i++ {
+		length, out := len(src), d.literals[min(i*segment, size):]
+		if i < 3 {
+			length = int(binary.LittleEndian.Uint16(jumps[2*i:]))
+			out = out[:segment]
+		}
+		if length > len(src) {
+			return 0, zstdError("truncated literals")
+		}
+		if err := d.huffman.decode(out, src[:length]); err != nil {
+			return 0, err
+		}
+		src = src[length:]
+	}
+	return n + compressed, nil
+}
+
+func (d *zstdReader) literalBuffer(size int) []byte {
+	if cap(d.litBuf) < size {
+		d.litBuf = make([]byte, size)
+	}
+	return d.litBuf[:size]
+}
+
+// readSequences reads the sequences section of a block and executes them.
+func (d *zstdReader) readSequences(data []byte) error {
+	if len(data) == 0 {
+		return zstdError("missing sequences")
+	}
+	count, i := int(data[0]), 1
+	switch {
+	case count == 0:
+		if len(data) != 1 {
+			return zstdError("data after sequences")
+		}
+		if len(d.literals) > d.blockMax {
+			return zstdError("block too large")
+		}
+		d.hist = append(d.hist, d.literals...)
+		return nil
+	case count == 255:
+		if len(data) < 3 {
+			return zstdError("truncated sequences")
+		}
+		count, i = int(data[1])+int(data[2])<<8+0x7f00, 3
+	case count >= 128:
+		if len(data) < 2 {
+			return zstdError("truncated sequences")
+		}
+		count, i = (count-128)<<8+int(data[1]), 2
+	}
+
+	if len(data) <= i {
+		return zstdError("truncated sequences")
+	}
+	modes := data[i]
+	i++
+	if modes&3 != 0 {
+		return zstdError("reserved bits set")
+	}
+	for t := range d.tables {
+		k, err := d.readTable(t, modes>>(6-2*t)&3, data[i:])
+		if err != nil {
+			return err
+		}
+		i += k
+	}
+
+	var b zstdBits
+	if err := b.init(data[i:]); err != nil {
+		return err
+	}
+	ll, of, ml := d.tables[0], d.tables[1], d.tables[2]
+	llState, ofState, mlState := b.read(ll.log), b.read(of.log), b.read(ml.log)
+
+	start := len(d.hist)
+	literals := d.literals
+	for s := 0; s < count; s++ {
+		llEntry, ofEntry, mlEntry := ll.table[llState], of.table[ofState], ml.table[mlState]
+
+		offset := 1<<ofEntry.symbol + b.read(uint(ofEntry.symbol))
+		matchLen := zstdMatchBase[mlEntry.symbol] + b.read(zstdMatchBits[mlEntry.symbol])
+		litLen := zstdLiteralBase[llEntry.symbol] + b.read(zstdLiteralBits[llEntry.symbol])
+
+		if offset > 3 {
+			offset -= 3
+			d.rep = [3]int{offset, d.rep[0], d.rep[1]}
+		} else {
+			if litLen == 0 {
+				offset++
+			}
+			switch offset {
+			case 1:
+				offset = d.rep[0]
+			case 2:
+				offset = d.rep[1]
+				d.rep = [3]int{offset, d.rep[0], d.rep[2]}
+			default:
+				if offset == 3 {
+					offset = d.rep[2]
+				} else {
+					offset = d.rep[0] - 1
+				}
+				d.rep = [3]int{offset, d.rep[0], d.rep[1]}
+			}
+		}
+
+		if s < count-1 {
+			llState = int(llEntry.base) + b.read(uint(llEntry.bits))
+			mlState = int(mlEntry.base) + b.read(uint(mlEntry.bits))
+			ofState = int(ofEntry.base) + b.read(uint(ofEntry.bits))
+		}
+
+		if litLen > len(literals) {
+			return zstdError("not enough literals")
+		}
+		d.hist = append(d.hist, literals[:litLen]...)
+		literals = literals[litLen:]
+
+		produced := int64(len(d.hist) - start)
+		if offset <= 0 || offset > d.window || int64(offset) > d.written+produced {
+			return zstdError("invalid match offset")
+		}
+		if len(d.hist)-start+matchLen > d.blockMax {
+			return zstdError("block too large")
+		}
+		for matchLen > 0 {
+			from := len(d.hist) - offset
+			n := min(matchLen, offset)
+			d.hist = append(d.hist, d.hist[from:from+n]...)
+			matchLen -= n
+		}
+	}
+	if b.pos != 0 {
+		return zstdError("corrupt sequences")
+	}
+
+	if len(d.hist)-start+len(literals) > d.blockMax {
+		return zstdError("block too large")
+	}
+	d.hist = append(d.hist, literals...)
+	return nil
+}
+
+// readTable sets up the literal length, offset or match length table for a
+// block, and returns how many bytes it used.
+func (d *zstdReader) readTable(t int, mode byte, data []byte) (int, error) {
+	switch mode {
+	case 0:
+		d.tables[t] = &zstdPredefined[t]
+		return 0, nil
+	case 1:
+		if len(data) == 0 {
+			return 0, zstdError("truncated sequences")
+		}
+		if int(data[0]) > zstdMaxSymbol[t] {
+			return 0, zstdError("invalid sequence symbol")
+		}
+		own := &d.own[t]
+		own.log = 0
+		own.table = append(own.table[:0], zstdState{symbol: data[0]})
+		d.tables[t] = own
+		return 1, nil
+	case 2:
+		own := &d.own[t]
+		n, err := own.read(data, zstdMaxSymbol[t], zstdMaxLog[t])
+		if err != nil {
+			return 0, err
+		}
+		d.tables[t] = own
+		return n, nil
+	}
+	if d.tables[t] == nil {
+		return 0, zstdError("missing sequence table")
+	}
+	return 0, nil
+}
+
+var (
+	zstdLiteralBase = [36]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 18, 20, 22, 24, 28, 32, 40, 48, 64, 128, 256, 512, 1024, 2048, 4096, 8192, 16384, 32768, 65536}
+	zstdLiteralBits = [36]uint{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 1, 1, 1, 2, 2, 3, 3, 4, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	zstdMatchBase   = [53]int{3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 33, 34, 35, 37, 39, 41, 43, 47, 51, 59, 67, 83, 99, 131, 259, 515, 1027, 2051, 4099, 8195, 16387, 32771, 65539}
+	zstdMatchBits   = [53]uint{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 1, 1, 1, 2, 2, 3, 3, 4, 4, 5, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+
+	// The largest symbol and accuracy log of the literal length, offset and
+	// match length tables.
+	zstdMaxSymbol = [3]int{35, 31, 52}
+	zstdMaxLog    = [3]uint{9, 8, 9}
+
+	// zstdPredefined are the default literal length, offset and match length
+	// tables.
+	zstdPredefined = func() [3]zstdFSE {
+		var tables [3]zstdFSE
+		tables[0].build([]int{4, 3, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2, 2, 3, 2, 1, 1, 1, 1, 1, -1, -1, -1, -1}, 6)
+		tables[1].build([]int{1, 1, 1, 1, 1, 1, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, -1, -1, -1, -1, -1}, 5)
+		tables[2].build([]int{1, 4, 3, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, -1, -1, -1, -1, -1, -1, -1}, 6)
+		return tables
+	}()
+)
+
+// zstdBits reads a bitstream backwards, starting from the highest bit below
+// the final set bit.
+type zstdBits struct {
+	data []byte
+
+	// pos is the number of bits left, which is negative once more bits have
+	// been read than there are.
+	pos int
+}
+
+func (b *zstdBits) init(data []byte) error {
+	if len(data) == 0 || data[len(data)-1] == 0 {
+		return zstdError("invalid bitstream")
+	}
+	b.data = data
+	b.pos = 8*len(data) - 9 + bits.Len8(data[len(data)-1])
+	return nil
+}
+
+// peek returns the next `n` bits, up to 56. Bits before the start of the
+// stream are zero.
+func (b *zstdBits) peek(n uint) int {
+	start, shift := b.pos-int(n), uint(0)
+	if start < 0 {
+		if b.pos <= 0 {
+			return 0
+		}
+		start, shift = 0, uint(-start)
+	}
+
+	i := start >> 3
+	var v uint64
+	if i+8 <= len(b.data) {
+		v = binary.LittleEndian.Uint64(b.data[i:])
+	} else {
+		for j := len(b.data) - 1; j >= i; j-- {
+			v = v<<8 | uint64(b.data[j])
+		}
+	}
+	v >>= uint(start & 7)
+	return int(v&(1<<(n-shift)-1)) << shift
+}
+
+func (b *zstdBits) read(n uint) int {
+	v := b.peek(n)
+	b.pos -= int(n)
+	return v
+}
+
+// zstdState is an entry of an FSE decoding table.
+type zstdState struct {
+	symbol uint8
+	bits   uint8
+	base   uint16
+}
+
+// zstdFSE is an FSE decoding table.
+type zstdFSE struct {
+	log   uint
+	table []zstdState
+}
+
+// read reads a table description and returns its size in bytes.
+func (t *zstdFSE) read(data []byte, maxSymbol int, maxLog uint) (int, error) {
+	pos := 0
+	get := func(n uint) int {
+		v := 0
+		for i := uint(0); i < n; i++ {
+			if p := pos + int(i); p>>3 < len(data) {
+				v |= int(data[p>>3]>>(p&7)&1) << i
+			}
+		}
+		return v
+	}
+
+	log := uint(get(4)) + 5
+	pos = 4
+	if log > maxLog {
+		return 0, zstdError("accuracy log too large")
+	}
+
+	var counts [256]int
+	remaining, threshold, nbits := 1<<log+1, 1<<log, log+1
+	symbol, zero := 0, false
+	for remaining > 1 && symbol <= maxSymbol {
+		if zero {
+			// A zero count is followed by how many more zeros there are.
+			for {
+				repeat := get(2)
+				pos += 2
+				symbol += repeat
+				if repeat != 3 {
+					break
+				}
+			}
+			if symbol > maxSymbol {
+				return 0, zstdError("too many symbols")
+			}
+		}
+
+		limit := 2*threshold - 1 - remaining
+		count := get(nbits)
+		if count&(threshold-1) < limit {
+			count &= threshold - 1
+			pos += int(nbits) - 1
+		} else {
+			if count >= threshold {
+				count -= limit
+			}
+			pos += int(nbits)
+		}
+
+		// A count of -1 is a probability of less than one.
+		count--
+		remaining -= max(count, -count)
+		counts[symbol] = count
+		symbol++
+		zero = count == 0
+		for remaining < threshold {
+			nbits--
+			threshold >>= 1
+		}
+	}
+	if remaining != 1 || (pos+7)/8 > len(data) {
+		return 0, zstdError("invalid FSE table")
+	}
+	if err := t.build(counts[:symbol], log); err != nil {
+		return 0, err
+	}
+	return (pos + 7) / 8, nil
+}
+
+// build builds the decoding table from normalized symbol counts.
+func (t *zstdFSE) build(counts []int, log uint) error {
+	size := 1 << log
+	t.log = log
+	if cap(t.table) < size {
+		t.table = make([]zstdState, size)
+	}
+	t.table = t.table[:size]
+
+	// Symbols with a probability of less than one go at the end.
+	high := size - 1
+	next := make([]int, len(counts))
+	for s, c := range counts {
+		next[s] = c
+		if c == -1 {
+			t.table[high].symbol = uint8(s)
+			high--
+			next[s] = 1
+		}
+	}
+
+	pos, step := 0, size>>1+size>>3+3
+	for s, c := range counts {
+		for i := 0; i < c; i++ {
+			t.table[pos].symbol = uint8(s)
+			for pos = (pos + step) & (size - 1); pos > high; {
+				pos = (pos + step) & (size - 1)
+			}
+		}
+	}
+	if pos != 0 {
+		return zstdError("invalid FSE table")
+	}
+
+	for i := range t.table {
+		e := &t.table[i]
+		n := next[e.symbol]
+		next[e.symbol]++
+		e.bits = uint8(log + 1 - uint(bits.Len(uint(n))))
+		e.base = uint16(n<<e.bits - size)
+	}
+	return nil
+}
+
+// zstdHuffman is a Huffman decoding table for literals.
+type zstdHuffman struct {
+	// maxBits is zero until a table has been read.
+	maxBits uint
+
+	// table maps the next `maxBits` bits to a symbol and its length.
+	table []uint16
+}
+
+// read reads a Huffman tree description and returns its size in bytes.
+func (h *zstdHuffman) read(data []byte) (int, error) {
+	if len(data) == 0 {
+		return 0, zstdError("missing Huffman table")
+	}
+	var weights [256]uint8
+	n, size := 0, 1+int(data[0])
+
+	if data[0] >= 128 {
+		// Weights are stored as four bit numbers.
+		n = int(data[0]) - 127
+		size = 1 + (n+1)/2
+		if len(data) < size {
+			return 0, zstdError("truncated Huffman table")
+		}
+		for i := 0; i < n; i++ {
+			weights[i] = data[1+i/2] >> (4 - 4*(i&1)) & 15
+		}
+	} else {
+		// Weights are FSE compressed, using two interleaved states.
+		if len(data) < size {
+			return 0, zstdError("truncated Huffman table")
+		}
+		var t zstdFSE
+		k, err := t.read(data[1:size], 255, 6)
+		if err != nil {
+			return 0, err
+		}
+		var b zstdBits
+		if err := b.init(data[1+k : size]); err != nil {
+			return 0, err
+		}
+		states := [2]int{b.read(t.log), b.read(t.log)}
+		for i := 0; ; i ^= 1 {
+			if n > 253 {
+				return 0, zstdError("too many Huffman weights")
+			}
+			e := t.table[states[i]]
+			weights[n] = e.symbol
+			n++
+			states[i] = int(e.base) + b.read(uint(e.bits))
+			if b.pos < 0 {
+				weights[n] = t.table[states[i^1]].symbol
+				n++
+				break
+			}
+		}
+	}
+
+	// The weight of the last symbol is implied by the others.
+	total := 0
+	for _, w := range weights[:n] {
+		if w > 11 {
+			return 0, zstdError("invalid Huffman weight")
+		}
+		if w > 0 {
+			total += 1 << (w - 1)
+		}
+	}
+	if total == 0 {
+		return 0, zstdError("invalid Huffman weights")
+	}
+	maxBits := uint(bits.Len(uint(total)))
+	rest := 1<<maxBits - total
+	if maxBits > 11 || rest&(rest-1) != 0 {
+		return 0, zstdError("invalid Huffman weights")
+	}
+	weights[n] = uint8(bits.Len(uint(rest)))
+	n++
+
+	h.maxBits = maxBits
+	if cap(h.table) < 1<<maxBits {
+		h.table = make([]uint16, 1<<maxBits)
+	}
+	h.table = h.table[:1<<maxBits]
+	pos := 0
+	for w := uint8(1); w <= uint8(maxBits); w++ {
+		for s, sw := range weights[:n] {
+			if sw != w {
+				continue
+			}
+			entry := uint16(s)<<8 | uint16(maxBits+1-uint(w))
+			for i := 0; i < 1<<(w-1); i++ {
+				h.table[pos] = entry
+				pos++
+			}
+		}
+	}
+	return size, nil
+}
+
+// decode fills `out` from a Huffman coded stream, which must be used up
+// exactly.
+func (h *zstdHuffman) decode(out, data []byte) error {
+	var b zstdBits
+	if err := b.init(data); err != nil {
+		return err
+	}
+	for i := range out {
+		e := h.table[b.peek(h.maxBits)]
+		out[i] = byte(e >> 8)
+		b.pos -= int(e & 0xff)
+	}
+	if b.pos != 0 {
+		return zstdError("corrupt literals")
+	}
+	return nil
+}
+
+// xxh64 computes the XXH64 hash which zstd uses for content checksums.
+type xxh64 struct {
+	v     [4]uint64
+	buf   [32]byte
+	n     int
+	total uint64
+}
+
+const (
+	xxhPrime1 uint64 = 0x9e3779b185ebca87
+	xxhPrime2 uint64 = 0xc2b2ae3d27d4eb4f
+	xxhPrime3 uint64 = 0x165667b19e3779f9
+	xxhPrime4 uint64 = 0x85ebca77c2b2ae63
+	xxhPrime5 uint64 = 0x27d4eb2f165667c5
+)
+
+func xxhRound(acc, input uint64) uint64 {
+	return bits.RotateLeft64(acc+input*xxhPrime2, 31) * xxhPrime1
+}
+
+func (x *xxh64) reset() {
+	prime1 := xxhPrime1
+	*x = xxh64{v: [4]uint64{prime1 + xxhPrime2, xxhPrime2, 0, -prime1}}
+}
+
+func (x *xxh64) write(p []byte) {
+	x.total += uint64(len(p))
+	if x.n > 0 {
+		c := copy(x.buf[x.n:], p)
+		x.n += c
+		p = p[c:]
+		if x.n < 32 {
+			return
+		}
+		x.stripe(x.buf[:])
+		x.n = 0
+	}
+	for ; len(p) >= 32; p = p[32:] {
+		x.stripe(p)
+	}
+	x.n = copy(x.buf[:], p)
+}
+
+func (x *xxh64) stripe(p []byte) {
+	for i := range x.v {
+		x.v[i] = xxhRound(x.v[i], binary.LittleEndian.Uint64(p[8*i:]))
+	}
+}
+
+func (x *xxh64) sum() uint64 {
+	var h uint64
+	if x.total >= 32 {
+		h = bits.RotateLeft64(x.v[0], 1) + bits.RotateLeft64(x.v[1], 7) + bits.RotateLeft64(x.v[2], 12) + bits.RotateLeft64(x.v[3], 18)
+		for _, v := range x.v {
+			h = (h^xxhRound(0, v))*xxhPrime1 + xxhPrime4
+		}
+	} else {
+		h = xxhPrime5
+	}
+	h += x.total
+
+	p := x.buf[:x.n]
+	for ; len(p) >= 8; p = p[8:] {
+		h = bits.RotateLeft64(h^xxhRound(0, binary.LittleEndian.Uint64(p)), 27)*xxhPrime1 + xxhPrime4
+	}
+	if len(p) >= 4 {
+		h = bits.RotateLeft64(h^uint64(binary.LittleEndian.Uint32(p))*xxhPrime1, 23)*xxhPrime2 + xxhPrime3
+		p = p[4:]
+	}
+	for _, c := range p {
+		h = bits.RotateLeft64(h^uint64(c)*xxhPrime5, 11) * xxhPrime1
+	}
+
+	h ^= h >> 33
+	h *= xxhPrime2
+	h ^= h >> 29
+	h *= xxhPrime3
+	h ^= h >> 32
+	return h
+}
@@ -0,0 +1,93 @@
+package pokesdk_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/danielgtaylor/pokesdk"
+)
+
+// Hand made zstd frames.
+const (
+	zstdRaw       = "\x28\xb5\x2f\xfd\x20\x02\x11\x00\x00hi"
+	zstdRLE       = "\x28\xb5\x2f\xfd\x20\x05\x2b\x00\x00a"
+	zstdSkippable = "\x50\x2a\x4d\x18\x04\x00\x00\x00skip"
+)
+
+func TestZstdDecompressor(t *testing.T) {
+	pikachu, err := os.ReadFile("testdata/pokemon-pikachu.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	compressed, err := os.ReadFile("testdata/pokemon-pikachu.json.zst")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		file     string
+		data     string
+		expected []byte
+	}{
+		{name: "compressed", file: "testdata/pokemon-pikachu.json.zst", expected: pikachu},
+		{name: "sliding window", file: "testdata/pokemon-list.ndjson.zst", expected: pokemonList()},
+		{name: "raw", data: zstdRaw, expected: []byte("hi")},
+		{name: "rle", data: zstdRLE, expected: []byte("aaaaa")},
+		{name: "skippable", data: zstdSkippable, expected: []byte{}},
+		{name: "frames", data: zstdSkippable + zstdRaw + zstdSkippable + zstdRLE, expected: []byte("hiaaaaa")},
+		{name: "frames after compressed", data: string(compressed) + zstdRaw, expected: append(bytes.Clone(pikachu), "hi"...)},
+	}
+
+	for _, test := range tests {
+		data := []byte(test.data)
+		if test.file != "" {
+			if data, err = os.ReadFile(test.file); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		actual, err := decompress(pokesdk.ZstdDecompressor, data)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if !bytes.Equal(actual, test.expected) {
+			t.Errorf("%s: expected %d bytes, got %d", test.name, len(test.expected), len(actual))
+		}
+	}
+}
+
+func TestZstdDecompressorErrors(t *testing.T) {
+	pikachu, err := os.ReadFile("testdata/pokemon-pikachu.json.zst")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checksum := bytes.Clone(pikachu)
+	checksum[len(checksum)-1] ^= 0xff
+
+	tests := map[string][]byte{
+		"empty":               {},
+		"truncated":           pikachu[:len(pikachu)/2],
+		"checksum":            checksum,
+		"trailing data":       []byte(zstdRaw + "junk"),
+		"dictionary":          []byte("\x28\xb5\x2f\xfd\x21\x01\x02\x11\x00\x00hi"),
+		"window too large":    []byte("\x28\xb5\x2f\xfd\x00\x90\x11\x00\x00hi"),
+		"reserved block type": []byte("\x28\xb5\x2f\xfd\x20\x02\x17\x00\x00hi"),
+		"content size":        []byte("\x28\xb5\x2f\xfd\x20\x03\x11\x00\x00hi"),
+	}
+
+	for name, data := range tests {
+		if _, err := decompress(pokesdk.ZstdDecompressor, data); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+
+	if _, err := decompress(pokesdk.ZstdDecompressor, pikachu[:len(pikachu)/2]); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("expected unexpected EOF for truncated data, got %v", err)
+	}
+}